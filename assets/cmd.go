@@ -3,11 +3,10 @@
 package assets
 
 import (
-	"bufio"
 	"errors"
 	"fmt"
 	"log/slog"
-	"os"
+	"strings"
 
 	"github.com/brimstone/plextraccli/plextrac"
 	"github.com/brimstone/plextraccli/utils"
@@ -16,6 +15,8 @@ import (
 	"github.com/spf13/viper"
 )
 
+var defaultCols = []string{"asset", "ports", "locations", "status"}
+
 func Cmd() *cobra.Command {
 	var cmd = &cobra.Command{
 		Use:   "assets",
@@ -24,24 +25,54 @@ func Cmd() *cobra.Command {
 		RunE:  cmdAssets,
 	}
 
+	cmd.PersistentFlags().String("cols", strings.Join(defaultCols, ","), "Columns to show")
+
 	// Add subcommand
 	addCmd := &cobra.Command{
-		Use:  "add",
+		Use:   "add [asset...]",
+		Short: "Add assets to a finding",
+		Long: `Add assets to a finding. Assets may be given as host, host:port,
+host:port/proto, host:port/proto/service or as a url, in which case the
+port and location are recorded on the finding as well.`,
 		RunE: cmdAssetsAdd,
 	}
 	addCmd.Flags().StringP("value", "v", "", "Value")
 	cmd.AddCommand(addCmd)
 
+	// Remove subcommand
+	removeCmd := &cobra.Command{
+		Use:   "rm [pattern...]",
+		Short: "Remove assets from a finding by value, glob or CIDR",
+		Aliases: []string{
+			"remove",
+			"delete",
+			"del",
+		},
+		RunE: cmdAssetsRemove,
+	}
+	cmd.AddCommand(removeCmd)
+
+	// Set subcommand
+	setCmd := &cobra.Command{
+		Use:   "set [asset...]",
+		Short: "Replace all assets of a finding",
+		Long: `Replace all assets of a finding with the assets given, or read from
+stdin if none are. Removing every asset takes --clear.`,
+		RunE: cmdAssetsSet,
+	}
+	setCmd.Flags().Bool("clear", false, "Remove every asset from the finding")
+	cmd.AddCommand(setCmd)
+
+	cmd.AddCommand(inventoryCmd())
+	cmd.AddCommand(importCmd())
+
 	return cmd
 }
 
 func assetArgs() (*plextrac.Finding, error) {
 	var f *plextrac.Finding
 
-	p, warnings, err := utils.NewPlextrac()
-	if err != nil {
-		return f, err
-	}
+	_, r, warnings, err := utils.GetReport()
 
 	for _, warning := range warnings {
 		slog.Warn("Warning while creating plextrac instance",
@@ -49,27 +80,10 @@ func assetArgs() (*plextrac.Finding, error) {
 		)
 	}
 
-	// Get Client
-	clientPartial := viper.GetString("client")
-	if clientPartial == "" {
-		return f, errors.New("must specify a client")
-	}
-
-	c, err := p.ClientByPartial(clientPartial)
 	if err != nil {
 		return f, err
 	}
 
-	// Get Report
-	reportPartial := viper.GetString("report")
-	if reportPartial == "" {
-		return f, errors.New("must specify a report")
-	}
-
-	r, _, err := c.ReportByPartial(reportPartial)
-	if err != nil {
-		return f, err
-	}
 	// Get Finding
 	findingPartial := viper.GetString("finding")
 	if findingPartial == "" {
@@ -90,17 +104,50 @@ func cmdAssets(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	assets, _, err := f.Assets()
+	assets, warnings, err := f.Assets()
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("Assets:\n")
+	for _, warning := range warnings {
+		slog.Warn("Warning while getting assets",
+			"warning", warning,
+		)
+	}
 
-	for _, v := range assets {
-		fmt.Printf("- %s\n", v.Value)
+	showCols := utils.AggregateCols(defaultCols, cmd.Flag("cols").Value.String())
+
+	var rows [][]string
+
+	for _, a := range assets {
+		var ports []string
+		for _, p := range a.Ports {
+			ports = append(ports, p.String())
+		}
+
+		rows = append(rows, []string{
+			a.Value,
+			strings.Join(ports, ","),
+			strings.Join(a.Locations, ","),
+			a.Status,
+			a.Notes,
+			a.ID,
+		})
 	}
 
+	utils.ShowTable(
+		[]string{
+			"Asset",
+			"Ports",
+			"Locations",
+			"Status",
+			"Notes",
+			"ID",
+		},
+		rows,
+		showCols,
+	)
+
 	return nil
 }
 
@@ -110,29 +157,113 @@ func cmdAssetsAdd(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	var assets []string
+	// If args is empty, read from stdin until we can't
+	assets := args
+	if len(assets) == 0 {
+		assets, err = utils.StdinToStringSlice()
+		if err != nil {
+			return err
+		}
+	}
+
+	changes, warnings, err := f.AddAssetBulk(assets)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Added %d assets, updated %d, %d already present\n", len(changes.Added), len(changes.Updated), len(changes.Unchanged))
+
+	for _, warning := range warnings {
+		slog.Warn("Warning while adding assets",
+			"warning", warning,
+		)
+	}
+
+	return nil
+}
 
-	if len(args) == 0 {
-		scanner := bufio.NewScanner(os.Stdin)
-		for scanner.Scan() {
-			assets = append(assets, scanner.Text())
+func cmdAssetsRemove(cmd *cobra.Command, args []string) error {
+	f, err := assetArgs()
+	if err != nil {
+		return err
+	}
+
+	// If args is empty, read from stdin until we can't
+	patterns := args
+	if len(patterns) == 0 {
+		patterns, err = utils.StdinToStringSlice()
+		if err != nil {
+			return err
 		}
+	}
+
+	changes, warnings, err := f.RemoveAssets(patterns)
+	if err != nil {
+		return err
+	}
+
+	for _, a := range changes.Removed {
+		fmt.Printf("- %s\n", a.Value)
+	}
+
+	fmt.Printf("Removed %d assets, %d left\n", len(changes.Removed), len(changes.Unchanged))
+
+	for _, warning := range warnings {
+		slog.Warn("Warning while removing assets",
+			"warning", warning,
+		)
+	}
+
+	return nil
+}
+
+func cmdAssetsSet(cmd *cobra.Command, args []string) error {
+	clearAll, err := cmd.Flags().GetBool("clear")
+	if err != nil {
+		return err
+	}
 
-		if scanner.Err() != nil {
-			return scanner.Err()
+	// If args is empty, read from stdin until we can't
+	assets := args
+	if len(assets) == 0 && !clearAll {
+		assets, err = utils.StdinToStringSlice()
+		if err != nil {
+			return err
 		}
-	} else {
-		fmt.Printf("Got to add %#v\n", args)
+	}
 
-		assets = append(assets, args...)
+	switch {
+	case clearAll && len(assets) > 0:
+		return errors.New("--clear removes every asset, so can't be given assets")
+	case !clearAll && len(assets) == 0:
+		return errors.New("no assets given, use --clear to remove every asset")
 	}
 
-	fmt.Printf("Adding %#v\n", assets)
+	f, err := assetArgs()
+	if err != nil {
+		return err
+	}
 
-	err = f.AddAssetBulk(assets)
+	changes, warnings, err := f.SetAssets(assets)
 	if err != nil {
 		return err
 	}
 
+	for _, a := range changes.Added {
+		fmt.Printf("+ %s\n", a.Value)
+	}
+
+	for _, a := range changes.Removed {
+		fmt.Printf("- %s\n", a.Value)
+	}
+
+	fmt.Printf("Added %d assets, updated %d, removed %d, kept %d\n", len(changes.Added), len(changes.Updated), len(changes.Removed), len(changes.Unchanged))
+
+	for _, warning := range warnings {
+		slog.Warn("Warning while setting assets",
+			"warning", warning,
+		)
+	}
+
 	return nil
 }
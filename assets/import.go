@@ -0,0 +1,176 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package assets
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/brimstone/plextraccli/importer"
+	"github.com/brimstone/plextraccli/plextrac"
+
+	"github.com/spf13/cobra"
+)
+
+func importCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import [file]",
+		Short: "Import scan results as client assets",
+		Long: `Import scan results as client assets, from a file or stdin if no file
+or - is given. Hosts are added to the client's asset inventory, or have
+their missing details filled in if they're already there. Hosts are matched
+to existing assets by name, the way finding assets are.
+
+With --attach, hosts are also added to the finding given by --finding,
+along with their open ports. --port and --service limit which ports, and
+so which hosts, get attached.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: cmdAssetsImport,
+	}
+	cmd.Flags().String("format", "", "Format to import, one of: "+strings.Join(importer.Names(), ", ")+" (default detected)")
+	cmd.Flags().Bool("attach", false, "Also add the hosts to the finding")
+	cmd.Flags().IntSlice("port", nil, "Only attach hosts with these open ports")
+	cmd.Flags().StringSlice("service", nil, "Only attach hosts with these services")
+	cmd.Flags().BoolP("dry-run", "n", false, "Show what would change without changing it")
+
+	return cmd
+}
+
+func cmdAssetsImport(cmd *cobra.Command, args []string) error {
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return err
+	}
+
+	attach, err := cmd.Flags().GetBool("attach")
+	if err != nil {
+		return err
+	}
+
+	ports, err := cmd.Flags().GetIntSlice("port")
+	if err != nil {
+		return err
+	}
+
+	services, err := cmd.Flags().GetStringSlice("service")
+	if err != nil {
+		return err
+	}
+
+	dryRun, err := cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		return err
+	}
+
+	filename := "-"
+	if len(args) == 1 {
+		filename = args[0]
+	}
+
+	r := os.Stdin
+
+	if filename != "-" {
+		r, err = os.Open(filename)
+		if err != nil {
+			return err
+		}
+		defer r.Close()
+	}
+
+	i, result, err := importer.Parse(r, format)
+	if err != nil {
+		return err
+	}
+
+	hosts := result.Hosts
+	if len(hosts) == 0 {
+		return fmt.Errorf("no hosts to import, %s output only has findings", i.Name())
+	}
+
+	// The finding is found first, so a bad --finding doesn't leave the
+	// inventory changed and nothing attached
+	var f *plextrac.Finding
+
+	if attach {
+		f, err = assetArgs()
+		if err != nil {
+			return err
+		}
+	}
+
+	var inventory []*plextrac.InventoryAsset
+	for _, h := range hosts {
+		inventory = append(inventory, h.InventoryAsset())
+	}
+
+	c, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	changes, warnings, err := c.AddAssets(inventory, dryRun)
+	if err != nil {
+		return err
+	}
+
+	for _, warning := range warnings {
+		slog.Warn("Warning while importing assets",
+			"warning", warning,
+		)
+	}
+
+	for _, a := range changes.Created {
+		fmt.Printf("+ %s\n", a.Asset)
+	}
+
+	for _, a := range changes.Updated {
+		fmt.Printf("~ %s\n", a.Asset)
+	}
+
+	verb := "Created"
+	if dryRun {
+		verb = "Would create"
+	}
+
+	fmt.Printf("%s %d assets, updated %d, %d already present\n", verb, len(changes.Created), len(changes.Updated), len(changes.Unchanged))
+
+	if !attach {
+		return nil
+	}
+
+	var specs []string
+	for _, h := range importer.FilterPorts(hosts, ports, services) {
+		specs = append(specs, h.AssetSpecs()...)
+	}
+
+	if len(specs) == 0 {
+		fmt.Printf("No hosts match to attach\n")
+
+		return nil
+	}
+
+	if dryRun {
+		for _, s := range specs {
+			fmt.Printf("attach %s\n", s)
+		}
+
+		return nil
+	}
+
+	assetChanges, warnings, err := f.AddAssetBulk(specs)
+	if err != nil {
+		return err
+	}
+
+	for _, warning := range warnings {
+		slog.Warn("Warning while attaching assets",
+			"warning", warning,
+		)
+	}
+
+	fmt.Printf("Attached %d assets to %s, updated %d, %d already present\n", len(assetChanges.Added), f.Name, len(assetChanges.Updated), len(assetChanges.Unchanged))
+
+	return nil
+}
@@ -0,0 +1,368 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package assets
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/brimstone/plextraccli/plextrac"
+	"github.com/brimstone/plextraccli/utils"
+
+	"github.com/spf13/cobra"
+)
+
+var defaultInventoryCols = []string{"asset", "type", "hostname", "ips", "os", "tags"}
+
+var inventoryFormats = []string{"csv", "json"}
+
+// inventoryCSVHeader is the header of exported CSV files. Imports match
+// columns by these names, ignoring case.
+var inventoryCSVHeader = []string{"id", "asset", "type", "hostname", "ips", "os", "tags", "findings"}
+
+func inventoryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "inventory",
+		Short: "Manage the asset inventory of a client",
+		Long: `Manage the asset inventory of a client. Unlike the other assets
+commands, these only need a client.`,
+		RunE: cmdInventory,
+	}
+	cmd.PersistentFlags().String("cols", strings.Join(defaultInventoryCols, ","), "Columns to show")
+	cmd.PersistentFlags().Bool("findings", false, "Look up which findings reference each asset, which is slow")
+
+	exportCmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the asset inventory as CSV or JSON",
+		RunE:  cmdInventoryExport,
+	}
+	exportCmd.Flags().String("format", "csv", "Format to export, one of: "+strings.Join(inventoryFormats, ", "))
+	exportCmd.Flags().StringP("output", "o", "-", "File to write to, - for stdout")
+	cmd.AddCommand(exportCmd)
+
+	importCmd := &cobra.Command{
+		Use:   "import [file]",
+		Short: "Import assets from a CSV or JSON file",
+		Long: `Import assets from a CSV or JSON file, as written by export, or stdin
+if no file or - is given. Host names and addresses are normalized, and
+assets already in the inventory are matched by host name or address and
+only get their missing details filled in.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: cmdInventoryImport,
+	}
+	importCmd.Flags().String("format", "", "Format to import, one of: "+strings.Join(inventoryFormats, ", ")+" (default from file extension)")
+	importCmd.Flags().BoolP("dry-run", "n", false, "Show what would change without changing it")
+	cmd.AddCommand(importCmd)
+
+	return cmd
+}
+
+func getClient() (*plextrac.Client, error) {
+	_, c, warnings, err := utils.GetClient()
+
+	for _, warning := range warnings {
+		slog.Warn("Warning while creating plextrac instance",
+			"warning", warning,
+		)
+	}
+
+	return c, err
+}
+
+func getInventory(cmd *cobra.Command) (*plextrac.Client, []*plextrac.InventoryAsset, error) {
+	c, err := getClient()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	inventory, warnings, err := c.Inventory()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	withFindings, err := cmd.Flags().GetBool("findings")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if withFindings {
+		warnings2, err := c.InventoryFindings(inventory)
+		warnings = append(warnings, warnings2...)
+
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	for _, warning := range warnings {
+		slog.Warn("Warning while getting inventory",
+			"warning", warning,
+		)
+	}
+
+	return c, inventory, nil
+}
+
+func cmdInventory(cmd *cobra.Command, args []string) error {
+	showCols := utils.AggregateCols(defaultInventoryCols, cmd.Flag("cols").Value.String())
+
+	// Asking for the column is as good as asking for the lookup
+	if slices.Contains(showCols, "findings") {
+		err := cmd.Flags().Set("findings", "true")
+		if err != nil {
+			return err
+		}
+	}
+
+	_, inventory, err := getInventory(cmd)
+	if err != nil {
+		return err
+	}
+
+	var rows [][]string
+
+	for _, a := range inventory {
+		rows = append(rows, []string{
+			a.Asset,
+			a.Type,
+			a.Hostname,
+			strings.Join(a.IPs, ","),
+			a.OS,
+			strings.Join(a.Tags, ","),
+			strings.Join(a.Findings, ", "),
+			a.ID,
+		})
+	}
+
+	utils.ShowTable(
+		[]string{
+			"Asset",
+			"Type",
+			"Hostname",
+			"IPs",
+			"OS",
+			"Tags",
+			"Findings",
+			"ID",
+		},
+		rows,
+		showCols,
+	)
+
+	return nil
+}
+
+func cmdInventoryExport(cmd *cobra.Command, args []string) error {
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return err
+	}
+
+	if !slices.Contains(inventoryFormats, format) {
+		return fmt.Errorf("unknown format %q, must be one of: %s", format, strings.Join(inventoryFormats, ", "))
+	}
+
+	output, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return err
+	}
+
+	_, inventory, err := getInventory(cmd)
+	if err != nil {
+		return err
+	}
+
+	w := os.Stdout
+
+	if output != "-" {
+		w, err = os.Create(output)
+		if err != nil {
+			return err
+		}
+		defer w.Close()
+	}
+
+	if format == "json" {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+
+		return enc.Encode(inventory)
+	}
+
+	return writeInventoryCSV(w, inventory)
+}
+
+func writeInventoryCSV(w io.Writer, inventory []*plextrac.InventoryAsset) error {
+	cw := csv.NewWriter(w)
+
+	err := cw.Write(inventoryCSVHeader)
+	if err != nil {
+		return err
+	}
+
+	for _, a := range inventory {
+		err = cw.Write([]string{
+			a.ID,
+			a.Asset,
+			a.Type,
+			a.Hostname,
+			strings.Join(a.IPs, ";"),
+			a.OS,
+			strings.Join(a.Tags, ";"),
+			strings.Join(a.Findings, ";"),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
+
+func readInventoryCSV(r io.Reader) ([]*plextrac.InventoryAsset, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	cols := make(map[string]int)
+	for i, h := range records[0] {
+		cols[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+
+	if _, ok := cols["asset"]; !ok {
+		if _, ok := cols["hostname"]; !ok {
+			return nil, errors.New("csv needs an asset or hostname column")
+		}
+	}
+
+	get := func(record []string, name string) string {
+		i, ok := cols[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+
+		return strings.TrimSpace(record[i])
+	}
+
+	list := func(record []string, name string) []string {
+		v := get(record, name)
+		if v == "" {
+			return nil
+		}
+
+		return strings.FieldsFunc(v, func(r rune) bool {
+			return r == ';' || r == ','
+		})
+	}
+
+	var inventory []*plextrac.InventoryAsset
+
+	for _, record := range records[1:] {
+		inventory = append(inventory, &plextrac.InventoryAsset{
+			Asset:    get(record, "asset"),
+			Type:     get(record, "type"),
+			Hostname: get(record, "hostname"),
+			IPs:      list(record, "ips"),
+			OS:       get(record, "os"),
+			Tags:     list(record, "tags"),
+		})
+	}
+
+	return inventory, nil
+}
+
+func cmdInventoryImport(cmd *cobra.Command, args []string) error {
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return err
+	}
+
+	dryRun, err := cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		return err
+	}
+
+	filename := "-"
+	if len(args) == 1 {
+		filename = args[0]
+	}
+
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(filename)), ".")
+	}
+
+	if !slices.Contains(inventoryFormats, format) {
+		return fmt.Errorf("unknown format %q, must be one of: %s", format, strings.Join(inventoryFormats, ", "))
+	}
+
+	r := os.Stdin
+
+	if filename != "-" {
+		r, err = os.Open(filename)
+		if err != nil {
+			return err
+		}
+		defer r.Close()
+	}
+
+	var inventory []*plextrac.InventoryAsset
+
+	if format == "json" {
+		err = json.NewDecoder(r).Decode(&inventory)
+	} else {
+		inventory, err = readInventoryCSV(r)
+	}
+
+	if err != nil {
+		return fmt.Errorf("unable to read %s: %w", filename, err)
+	}
+
+	c, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	changes, warnings, err := c.ImportInventory(inventory, dryRun)
+	if err != nil {
+		return err
+	}
+
+	for _, warning := range warnings {
+		slog.Warn("Warning while importing inventory",
+			"warning", warning,
+		)
+	}
+
+	for _, a := range changes.Created {
+		fmt.Printf("+ %s\n", a.Asset)
+	}
+
+	for _, a := range changes.Updated {
+		fmt.Printf("~ %s\n", a.Asset)
+	}
+
+	if dryRun {
+		fmt.Printf("Would create %d assets, update %d, %d already present\n", len(changes.Created), len(changes.Updated), len(changes.Unchanged))
+
+		return nil
+	}
+
+	fmt.Printf("Created %d assets, updated %d, %d already present\n", len(changes.Created), len(changes.Updated), len(changes.Unchanged))
+
+	return nil
+}
@@ -0,0 +1,281 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+// Package cvss parses CVSS v3.0 and v3.1 vectors, eg:
+// CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H, and calculates their base,
+// temporal and environmental scores as the FIRST specification does.
+package cvss
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+)
+
+// Versions are the CVSS versions that can be parsed.
+var Versions = []string{"3.0", "3.1"}
+
+// metric is a metric of a vector, and the weights of its values. Optional
+// metrics can be X, not defined.
+type metric struct {
+	name     string
+	required bool
+	values   map[string]float64
+}
+
+// metrics are the metrics of a vector, in the order they're written.
+var metrics = []metric{
+	{"AV", true, map[string]float64{"N": 0.85, "A": 0.62, "L": 0.55, "P": 0.2}},
+	{"AC", true, map[string]float64{"L": 0.77, "H": 0.44}},
+	// Privileges are worth more when the scope changes, see privileges
+	{"PR", true, map[string]float64{"N": 0.85, "L": 0.62, "H": 0.27}},
+	{"UI", true, map[string]float64{"N": 0.85, "R": 0.62}},
+	{"S", true, map[string]float64{"U": 0, "C": 0}},
+	{"C", true, map[string]float64{"H": 0.56, "L": 0.22, "N": 0}},
+	{"I", true, map[string]float64{"H": 0.56, "L": 0.22, "N": 0}},
+	{"A", true, map[string]float64{"H": 0.56, "L": 0.22, "N": 0}},
+	{"E", false, map[string]float64{"X": 1, "H": 1, "F": 0.97, "P": 0.94, "U": 0.91}},
+	{"RL", false, map[string]float64{"X": 1, "U": 1, "W": 0.97, "T": 0.96, "O": 0.95}},
+	{"RC", false, map[string]float64{"X": 1, "C": 1, "R": 0.96, "U": 0.92}},
+	{"CR", false, map[string]float64{"X": 1, "H": 1.5, "M": 1, "L": 0.5}},
+	{"IR", false, map[string]float64{"X": 1, "H": 1.5, "M": 1, "L": 0.5}},
+	{"AR", false, map[string]float64{"X": 1, "H": 1.5, "M": 1, "L": 0.5}},
+	{"MAV", false, map[string]float64{"X": 0, "N": 0.85, "A": 0.62, "L": 0.55, "P": 0.2}},
+	{"MAC", false, map[string]float64{"X": 0, "L": 0.77, "H": 0.44}},
+	{"MPR", false, map[string]float64{"X": 0, "N": 0.85, "L": 0.62, "H": 0.27}},
+	{"MUI", false, map[string]float64{"X": 0, "N": 0.85, "R": 0.62}},
+	{"MS", false, map[string]float64{"X": 0, "U": 0, "C": 0}},
+	{"MC", false, map[string]float64{"X": 0, "H": 0.56, "L": 0.22, "N": 0}},
+	{"MI", false, map[string]float64{"X": 0, "H": 0.56, "L": 0.22, "N": 0}},
+	{"MA", false, map[string]float64{"X": 0, "H": 0.56, "L": 0.22, "N": 0}},
+}
+
+// Vector is a parsed CVSS vector.
+type Vector struct {
+	Version string
+	// values are the values of the metrics in the vector, by name
+	values map[string]string
+}
+
+// Parse parses a CVSS v3 vector. The base metrics are required, and the
+// temporal and environmental ones optional.
+func Parse(vector string) (*Vector, error) {
+	vector = strings.TrimSpace(vector)
+	if vector == "" {
+		return nil, errors.New("empty vector")
+	}
+
+	prefix, rest, ok := strings.Cut(vector, "/")
+
+	version, isCVSS := strings.CutPrefix(prefix, "CVSS:")
+	if !ok || !isCVSS {
+		return nil, fmt.Errorf("vector %q must start with CVSS:3.1/", vector)
+	}
+
+	if !slices.Contains(Versions, version) {
+		return nil, fmt.Errorf("unsupported CVSS version %q, must be one of: %s", version, strings.Join(Versions, ", "))
+	}
+
+	v := &Vector{
+		Version: version,
+		values:  make(map[string]string),
+	}
+
+	for _, part := range strings.Split(rest, "/") {
+		name, value, ok := strings.Cut(part, ":")
+		if !ok {
+			return nil, fmt.Errorf("metric %q must be a name and value, eg: AV:N", part)
+		}
+
+		i := slices.IndexFunc(metrics, func(m metric) bool { return m.name == name })
+		if i == -1 {
+			return nil, fmt.Errorf("unknown metric %q", name)
+		}
+
+		if _, ok := metrics[i].values[value]; !ok {
+			return nil, fmt.Errorf("unknown value %q for metric %s", value, name)
+		}
+
+		if _, ok := v.values[name]; ok {
+			return nil, fmt.Errorf("metric %s is in the vector more than once", name)
+		}
+
+		v.values[name] = value
+	}
+
+	for _, m := range metrics {
+		if _, ok := v.values[m.name]; m.required && !ok {
+			return nil, fmt.Errorf("vector is missing metric %s", m.name)
+		}
+	}
+
+	return v, nil
+}
+
+// String is the vector, with the metrics in the usual order and without the
+// ones that aren't defined.
+func (v *Vector) String() string {
+	parts := []string{"CVSS:" + v.Version}
+
+	for _, m := range metrics {
+		if value, ok := v.values[m.name]; ok && value != "X" {
+			parts = append(parts, m.name+":"+value)
+		}
+	}
+
+	return strings.Join(parts, "/")
+}
+
+// Metric is the value of a metric, X if it isn't defined.
+func (v *Vector) Metric(name string) string {
+	if value, ok := v.values[name]; ok {
+		return value
+	}
+
+	return "X"
+}
+
+// weight is the weight of the metric's value.
+func (v *Vector) weight(name string) float64 {
+	i := slices.IndexFunc(metrics, func(m metric) bool { return m.name == name })
+
+	return metrics[i].values[v.Metric(name)]
+}
+
+// modified is the weight of the modified metric, or the base metric when the
+// modified one isn't defined.
+func (v *Vector) modified(name string) float64 {
+	if v.Metric("M"+name) == "X" {
+		return v.weight(name)
+	}
+
+	return v.weight("M" + name)
+}
+
+// changed reports whether the scope changes, for the base score or, when
+// modified, the environmental score.
+func (v *Vector) changed(modified bool) bool {
+	if modified && v.Metric("MS") != "X" {
+		return v.Metric("MS") == "C"
+	}
+
+	return v.Metric("S") == "C"
+}
+
+// privileges is the weight of the privileges required, which are worth more
+// when the scope changes.
+func privileges(value string, changed bool) float64 {
+	switch {
+	case changed && value == "L":
+		return 0.68
+	case changed && value == "H":
+		return 0.5
+	}
+
+	return metrics[slices.IndexFunc(metrics, func(m metric) bool { return m.name == "PR" })].values[value]
+}
+
+// BaseScore is the base score of the vector, from 0.0 to 10.0.
+func (v *Vector) BaseScore() float64 {
+	changed := v.changed(false)
+
+	iss := 1 - (1-v.weight("C"))*(1-v.weight("I"))*(1-v.weight("A"))
+
+	impact := 6.42 * iss
+	if changed {
+		impact = 7.52*(iss-0.029) - 3.25*math.Pow(iss-0.02, 15)
+	}
+
+	if impact <= 0 {
+		return 0
+	}
+
+	exploitability := 8.22 * v.weight("AV") * v.weight("AC") * privileges(v.Metric("PR"), changed) * v.weight("UI")
+
+	if changed {
+		return v.roundUp(min(1.08*(impact+exploitability), 10))
+	}
+
+	return v.roundUp(min(impact+exploitability, 10))
+}
+
+// temporal is how much the temporal metrics lower a score.
+func (v *Vector) temporal() float64 {
+	return v.weight("E") * v.weight("RL") * v.weight("RC")
+}
+
+// TemporalScore is the base score lowered by the temporal metrics.
+func (v *Vector) TemporalScore() float64 {
+	return v.roundUp(v.BaseScore() * v.temporal())
+}
+
+// EnvironmentalScore is the score with the modified metrics and security
+// requirements of the environment.
+func (v *Vector) EnvironmentalScore() float64 {
+	changed := v.changed(true)
+
+	miss := min(1-
+		(1-v.weight("CR")*v.modified("C"))*
+			(1-v.weight("IR")*v.modified("I"))*
+			(1-v.weight("AR")*v.modified("A")), 0.915)
+
+	impact := 6.42 * miss
+
+	if changed {
+		if v.Version == "3.0" {
+			impact = 7.52*(miss-0.029) - 3.25*math.Pow(miss-0.02, 15)
+		} else {
+			impact = 7.52*(miss-0.029) - 3.25*math.Pow(miss*0.9731-0.02, 13)
+		}
+	}
+
+	if impact <= 0 {
+		return 0
+	}
+
+	pr := v.Metric("MPR")
+	if pr == "X" {
+		pr = v.Metric("PR")
+	}
+
+	exploitability := 8.22 * v.modified("AV") * v.modified("AC") * privileges(pr, changed) * v.modified("UI")
+
+	score := impact + exploitability
+	if changed {
+		score *= 1.08
+	}
+
+	return v.roundUp(v.roundUp(min(score, 10)) * v.temporal())
+}
+
+// roundUp rounds up to one decimal place. CVSS 3.1 rounds in integers first,
+// so floating point errors don't round, eg: 4.000000001 up to 4.1.
+func (v *Vector) roundUp(score float64) float64 {
+	if v.Version == "3.0" {
+		return math.Ceil(score*10) / 10
+	}
+
+	i := int(math.Round(score * 100000))
+	if i%10000 == 0 {
+		return float64(i) / 100000
+	}
+
+	return float64(i/10000+1) / 10
+}
+
+// Rating is the qualitative severity rating of a score, eg: 9.8 is Critical.
+func Rating(score float64) string {
+	switch {
+	case score >= 9:
+		return "Critical"
+	case score >= 7:
+		return "High"
+	case score >= 4:
+		return "Medium"
+	case score > 0:
+		return "Low"
+	}
+
+	return "None"
+}
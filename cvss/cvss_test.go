@@ -0,0 +1,122 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package cvss_test
+
+import (
+	"testing"
+
+	"github.com/brimstone/plextraccli/cvss"
+)
+
+func TestParse_scores(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		vector        string
+		base          float64
+		temporal      float64
+		environmental float64
+	}{
+		{"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H", 9.8, 9.8, 9.8},
+		{"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:C/C:H/I:H/A:H", 10, 10, 10},
+		{"CVSS:3.1/AV:N/AC:L/PR:N/UI:R/S:C/C:L/I:L/A:N", 6.1, 6.1, 6.1},
+		{"CVSS:3.1/AV:L/AC:L/PR:L/UI:N/S:U/C:H/I:H/A:H", 7.8, 7.8, 7.8},
+		{"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:N/A:N", 7.5, 7.5, 7.5},
+		{"CVSS:3.1/AV:N/AC:H/PR:N/UI:N/S:U/C:N/I:N/A:N", 0, 0, 0},
+		{"CVSS:3.0/AV:N/AC:L/PR:L/UI:N/S:C/C:L/I:L/A:N", 6.4, 6.4, 6.4},
+		{"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H/E:P/RL:O/RC:C", 9.8, 8.8, 8.8},
+		{"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H/CR:L/IR:L/AR:L", 9.8, 9.8, 8},
+		{"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H/MAV:L/MPR:H", 9.8, 9.8, 6.7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.vector, func(t *testing.T) {
+			t.Parallel()
+
+			v, err := cvss.Parse(tt.vector)
+			if err != nil {
+				t.Fatalf("Parse() returned error: %v", err)
+			}
+
+			if got := v.BaseScore(); got != tt.base {
+				t.Errorf("BaseScore() = %v, want %v", got, tt.base)
+			}
+
+			if got := v.TemporalScore(); got != tt.temporal {
+				t.Errorf("TemporalScore() = %v, want %v", got, tt.temporal)
+			}
+
+			if got := v.EnvironmentalScore(); got != tt.environmental {
+				t.Errorf("EnvironmentalScore() = %v, want %v", got, tt.environmental)
+			}
+		})
+	}
+}
+
+func TestParse_errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		vector string
+	}{
+		{"empty", ""},
+		{"no prefix", "AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H"},
+		{"version 2", "CVSS:2.0/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H"},
+		{"missing metric", "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H"},
+		{"unknown metric", "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H/Au:N"},
+		{"unknown value", "CVSS:3.1/AV:X/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H"},
+		{"duplicate", "CVSS:3.1/AV:N/AV:L/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H"},
+		{"no value", "CVSS:3.1/AV/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := cvss.Parse(tt.vector)
+			if err == nil {
+				t.Errorf("Parse(%q) returned no error", tt.vector)
+			}
+		})
+	}
+}
+
+func TestVector_String(t *testing.T) {
+	t.Parallel()
+
+	v, err := cvss.Parse("CVSS:3.1/A:H/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/E:X")
+	if err != nil {
+		t.Fatalf("Parse() returned error: %v", err)
+	}
+
+	expected := "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H"
+	if got := v.String(); got != expected {
+		t.Errorf("String() = %q, want %q", got, expected)
+	}
+}
+
+func TestRating(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		score    float64
+		expected string
+	}{
+		{0, "None"},
+		{0.1, "Low"},
+		{3.9, "Low"},
+		{4, "Medium"},
+		{6.9, "Medium"},
+		{7, "High"},
+		{8.9, "High"},
+		{9, "Critical"},
+		{10, "Critical"},
+	}
+
+	for _, tt := range tests {
+		if got := cvss.Rating(tt.score); got != tt.expected {
+			t.Errorf("Rating(%v) = %q, want %q", tt.score, got, tt.expected)
+		}
+	}
+}
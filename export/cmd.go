@@ -50,17 +50,7 @@ func cmdExport(cmd *cobra.Command, args []string) error {
 
 	templateName := cmd.Flag("template").Value.String()
 
-	p, warnings, err := utils.NewPlextrac()
-	if err != nil {
-		return err
-	}
-	// Get Client
-	clientPartial := viper.GetString("client")
-	if clientPartial == "" {
-		return errors.New("must specify a client")
-	}
-
-	c, err := p.ClientByPartial(clientPartial)
+	_, c, warnings, err := utils.GetClient()
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,374 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package findings
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/brimstone/plextraccli/plextrac"
+	"github.com/brimstone/plextraccli/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func bulkCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bulk",
+		Short: "Change many findings of a report at once",
+		Long: `Change many findings of a report at once.
+
+Findings are selected with the --match flags, which all have to match. With
+no --match flags every finding in the report is selected.`,
+		RunE: cmdFindingsBulk,
+	}
+
+	// Selectors
+	cmd.Flags().String("match-name", "", "Glob for finding names, eg: '*SMB*'")
+	cmd.Flags().StringSlice("match-severity", nil, "Severities to select")
+	cmd.Flags().StringSlice("match-status", nil, "Statuses to select")
+	cmd.Flags().String("match-tag", "", "Tag the finding must have")
+	cmd.Flags().Bool("match-published", false, "Select published findings, or --match-published=false for drafts")
+
+	// Actions
+	cmd.Flags().Bool("publish", false, "Publish the findings")
+	cmd.Flags().Bool("unpublish", false, "Make the findings drafts")
+	cmd.Flags().String("status", "", "Set the status. One of: "+strings.Join(plextrac.Statuses, ",")+".")
+	cmd.Flags().StringSlice("add-tag", nil, "Tags to add")
+	cmd.Flags().StringSlice("remove-tag", nil, "Tags to remove")
+
+	cmd.Flags().BoolP("dry-run", "n", false, "Show what would change without changing anything")
+
+	return cmd
+}
+
+type findingSelector struct {
+	name       string
+	severities []string
+	statuses   []string
+	tag        string
+	published  *bool
+}
+
+func (s findingSelector) needsTags() bool {
+	return s.tag != ""
+}
+
+// match reports whether the finding is selected. The tags are only needed
+// when the selector needsTags, as getting them is another request.
+func (s findingSelector) match(f *plextrac.Finding, tags []string) bool {
+	if s.name != "" {
+		ok, _ := path.Match(strings.ToLower(s.name), strings.ToLower(f.Name))
+		if !ok {
+			return false
+		}
+	}
+
+	equalFold := func(v string) func(string) bool {
+		return func(s string) bool { return strings.EqualFold(s, v) }
+	}
+
+	if len(s.severities) > 0 && !slices.ContainsFunc(s.severities, equalFold(f.Severity)) {
+		return false
+	}
+
+	if len(s.statuses) > 0 && !slices.ContainsFunc(s.statuses, equalFold(f.Status)) {
+		return false
+	}
+
+	if s.published != nil && *s.published != f.Published {
+		return false
+	}
+
+	if s.tag != "" && !slices.Contains(tags, s.tag) {
+		return false
+	}
+
+	return true
+}
+
+type bulkChange struct {
+	description string
+	apply       func() ([]error, error)
+}
+
+type bulkActions struct {
+	publish    bool
+	unpublish  bool
+	status     string
+	addTags    []string
+	removeTags []string
+}
+
+func (a bulkActions) needsTags() bool {
+	return len(a.addTags) > 0 || len(a.removeTags) > 0
+}
+
+// changes works out what has to change on a finding with the tags, so
+// findings already in the right state are left alone.
+func (a bulkActions) changes(f *plextrac.Finding, tags []string) []bulkChange {
+	var changes []bulkChange
+
+	// Publishing and the status are one update, so they can't half happen
+	var (
+		update       plextrac.FindingUpdate
+		descriptions []string
+	)
+
+	if a.publish && !f.Published {
+		published := true
+		update.Published = &published
+		descriptions = append(descriptions, "publish")
+	}
+
+	if a.unpublish && f.Published {
+		published := false
+		update.Published = &published
+		descriptions = append(descriptions, "unpublish")
+	}
+
+	if a.status != "" && !strings.EqualFold(a.status, f.Status) {
+		status := a.status
+		update.Status = &status
+		descriptions = append(descriptions, "status "+f.Status+" -> "+status)
+	}
+
+	if len(descriptions) > 0 {
+		changes = append(changes, bulkChange{strings.Join(descriptions, ", "), func() ([]error, error) { return f.Update(update) }})
+	}
+
+	var addTags []string
+
+	for _, t := range a.addTags {
+		if !slices.Contains(tags, t) {
+			addTags = append(addTags, t)
+		}
+	}
+
+	if len(addTags) > 0 {
+		changes = append(changes, bulkChange{"add tags " + strings.Join(addTags, ","), func() ([]error, error) { return f.AddTags(addTags) }})
+	}
+
+	var removeTags []string
+
+	for _, t := range a.removeTags {
+		if slices.Contains(tags, t) {
+			removeTags = append(removeTags, t)
+		}
+	}
+
+	if len(removeTags) > 0 {
+		changes = append(changes, bulkChange{"remove tags " + strings.Join(removeTags, ","), func() ([]error, error) { return f.RemoveTags(removeTags) }})
+	}
+
+	return changes
+}
+
+// bulkPlan is the changes to make to a finding.
+type bulkPlan struct {
+	name    string
+	changes []bulkChange
+}
+
+type bulkResult struct {
+	changed   int
+	unchanged int
+	failed    int
+	warnings  []error
+}
+
+// applyBulk makes the planned changes, or only describes them in a dry run,
+// writing a line for each finding.
+func applyBulk(w io.Writer, plans []bulkPlan, dryRun bool) bulkResult {
+	var result bulkResult
+
+	for i, p := range plans {
+		prefix := fmt.Sprintf("[%d/%d] %s", i+1, len(plans), p.name)
+
+		if len(p.changes) == 0 {
+			fmt.Fprintf(w, "%s: unchanged\n", prefix)
+
+			result.unchanged++
+
+			continue
+		}
+
+		var (
+			failure error
+			applied []string
+		)
+
+		for _, c := range p.changes {
+			if dryRun {
+				continue
+			}
+
+			warnings, err := c.apply()
+			result.warnings = append(result.warnings, warnings...)
+
+			if err != nil {
+				failure = fmt.Errorf("%s: %w", c.description, err)
+
+				break
+			}
+
+			applied = append(applied, c.description)
+		}
+
+		if failure != nil {
+			done := "nothing applied"
+			if len(applied) > 0 {
+				done = "applied " + strings.Join(applied, ", ")
+			}
+
+			fmt.Fprintf(w, "%s: failed: %s (%s)\n", prefix, failure, done)
+
+			result.failed++
+
+			continue
+		}
+
+		var descriptions []string
+		for _, c := range p.changes {
+			descriptions = append(descriptions, c.description)
+		}
+
+		fmt.Fprintf(w, "%s: %s\n", prefix, strings.Join(descriptions, ", "))
+
+		result.changed++
+	}
+
+	return result
+}
+
+func cmdFindingsBulk(cmd *cobra.Command, args []string) error {
+	var (
+		selector findingSelector
+		actions  bulkActions
+		err      error
+	)
+
+	selector.name = cmd.Flag("match-name").Value.String()
+	selector.tag = cmd.Flag("match-tag").Value.String()
+
+	selector.severities, err = cmd.Flags().GetStringSlice("match-severity")
+	if err != nil {
+		return err
+	}
+
+	selector.statuses, err = cmd.Flags().GetStringSlice("match-status")
+	if err != nil {
+		return err
+	}
+
+	if cmd.Flag("match-published").Changed {
+		published, err := cmd.Flags().GetBool("match-published")
+		if err != nil {
+			return err
+		}
+
+		selector.published = &published
+	}
+
+	_, err = path.Match(selector.name, "")
+	if err != nil {
+		return fmt.Errorf("bad --match-name: %w", err)
+	}
+
+	actions.publish, err = cmd.Flags().GetBool("publish")
+	if err != nil {
+		return err
+	}
+
+	actions.unpublish, err = cmd.Flags().GetBool("unpublish")
+	if err != nil {
+		return err
+	}
+
+	if actions.publish && actions.unpublish {
+		return errors.New("can't both publish and unpublish")
+	}
+
+	actions.status = cmd.Flag("status").Value.String()
+	if actions.status != "" && !slices.ContainsFunc(plextrac.Statuses, func(s string) bool { return strings.EqualFold(s, actions.status) }) {
+		return fmt.Errorf("unknown status %q, must be one of: %s", actions.status, strings.Join(plextrac.Statuses, ", "))
+	}
+
+	actions.addTags, err = cmd.Flags().GetStringSlice("add-tag")
+	if err != nil {
+		return err
+	}
+
+	actions.removeTags, err = cmd.Flags().GetStringSlice("remove-tag")
+	if err != nil {
+		return err
+	}
+
+	if !actions.publish && !actions.unpublish && actions.status == "" && len(actions.addTags) == 0 && len(actions.removeTags) == 0 {
+		return errors.New("no action given")
+	}
+
+	dryRun, err := cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		return err
+	}
+
+	_, r, warnings, err := utils.GetReport()
+	if err != nil {
+		return err
+	}
+
+	findings, warnings2, err := r.Findings()
+	if err != nil {
+		return err
+	}
+
+	warnings = append(warnings, warnings2...)
+
+	var plans []bulkPlan
+
+	for _, f := range findings {
+		var tags []string
+		if selector.needsTags() {
+			tags = f.Tags()
+		}
+
+		if !selector.match(f, tags) {
+			continue
+		}
+
+		if actions.needsTags() {
+			tags = f.Tags()
+		}
+
+		plans = append(plans, bulkPlan{name: f.Name, changes: actions.changes(f, tags)})
+	}
+
+	result := applyBulk(cmd.OutOrStdout(), plans, dryRun)
+	warnings = append(warnings, result.warnings...)
+
+	verb := "Changed"
+	if dryRun {
+		verb = "Would change"
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "%s %d of %d selected findings (%d total), %d unchanged, %d failed\n",
+		verb, result.changed, len(plans), len(findings), result.unchanged, result.failed)
+
+	for _, warning := range warnings {
+		slog.Warn("Warning while changing findings",
+			"warning", warning,
+		)
+	}
+
+	if result.failed > 0 {
+		return fmt.Errorf("%d findings failed to update", result.failed)
+	}
+
+	return nil
+}
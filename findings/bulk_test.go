@@ -0,0 +1,187 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package findings
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/brimstone/plextraccli/plextrac"
+)
+
+func TestFindingSelector_match(t *testing.T) {
+	t.Parallel()
+
+	published, draft := true, false
+
+	f := &plextrac.Finding{Name: "SMB Signing Not Required", Severity: "High", Status: "Open", Published: true}
+	tags := []string{"scope_ipt", "ad"}
+
+	tests := []struct {
+		name     string
+		selector findingSelector
+		expected bool
+	}{
+		{"everything", findingSelector{}, true},
+		{"name glob", findingSelector{name: "*smb*"}, true},
+		{"name glob misses", findingSelector{name: "*ldap*"}, false},
+		{"severity", findingSelector{severities: []string{"critical", "high"}}, true},
+		{"severity misses", findingSelector{severities: []string{"low"}}, false},
+		{"status", findingSelector{statuses: []string{"open"}}, true},
+		{"status misses", findingSelector{statuses: []string{"Closed"}}, false},
+		{"published", findingSelector{published: &published}, true},
+		{"drafts", findingSelector{published: &draft}, false},
+		{"tag", findingSelector{tag: "ad"}, true},
+		{"tag misses", findingSelector{tag: "web"}, false},
+		{"all have to match", findingSelector{name: "*SMB*", severities: []string{"low"}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.selector.match(f, tags); got != tt.expected {
+				t.Errorf("match() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestBulkActions_changes(t *testing.T) {
+	t.Parallel()
+
+	f := &plextrac.Finding{Name: "SMB Signing", Status: "Open", Published: false}
+	tags := []string{"scope_ipt", "ad"}
+
+	tests := []struct {
+		name     string
+		actions  bulkActions
+		expected []string
+	}{
+		{"publish", bulkActions{publish: true}, []string{"publish"}},
+		{"already a draft", bulkActions{unpublish: true}, nil},
+		{"status", bulkActions{status: "Closed"}, []string{"status Open -> Closed"}},
+		{"same status", bulkActions{status: "open"}, nil},
+		{"add tags", bulkActions{addTags: []string{"ad", "smb"}}, []string{"add tags smb"}},
+		{"remove tags", bulkActions{removeTags: []string{"ad", "web"}}, []string{"remove tags ad"}},
+		{
+			"everything",
+			bulkActions{publish: true, status: "Closed", addTags: []string{"smb"}, removeTags: []string{"ad"}},
+			[]string{"publish, status Open -> Closed", "add tags smb", "remove tags ad"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got []string
+			for _, c := range tt.actions.changes(f, tags) {
+				got = append(got, c.description)
+			}
+
+			if !slices.Equal(got, tt.expected) {
+				t.Errorf("changes() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestApplyBulk(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		dryRun   bool
+		applied  int
+		result   bulkResult
+		expected string
+	}{
+		{
+			name:     "dry run",
+			dryRun:   true,
+			applied:  0,
+			result:   bulkResult{changed: 2, unchanged: 1},
+			expected: "[1/3] A: publish, add tags smb\n[2/3] B: unchanged\n[3/3] C: publish\n",
+		},
+		{
+			name:     "applied",
+			applied:  3,
+			result:   bulkResult{changed: 2, unchanged: 1},
+			expected: "[1/3] A: publish, add tags smb\n[2/3] B: unchanged\n[3/3] C: publish\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			applied := 0
+			apply := func() ([]error, error) {
+				applied++
+
+				return nil, nil
+			}
+
+			plans := []bulkPlan{
+				{name: "A", changes: []bulkChange{{"publish", apply}, {"add tags smb", apply}}},
+				{name: "B"},
+				{name: "C", changes: []bulkChange{{"publish", apply}}},
+			}
+
+			var out bytes.Buffer
+
+			result := applyBulk(&out, plans, tt.dryRun)
+
+			if applied != tt.applied {
+				t.Errorf("applied %d changes, want %d", applied, tt.applied)
+			}
+
+			if result.changed != tt.result.changed || result.unchanged != tt.result.unchanged || result.failed != tt.result.failed {
+				t.Errorf("result = %+v, want %+v", result, tt.result)
+			}
+
+			if out.String() != tt.expected {
+				t.Errorf("output = %q, want %q", out.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestApplyBulk_failure(t *testing.T) {
+	t.Parallel()
+
+	applied := 0
+
+	ok := func() ([]error, error) { applied++; return nil, nil }
+	fail := func() ([]error, error) { return []error{errors.New("odd field")}, errors.New("boom") }
+
+	plans := []bulkPlan{
+		{name: "A", changes: []bulkChange{{"publish", fail}, {"add tags smb", ok}}},
+		{name: "B", changes: []bulkChange{{"publish", ok}, {"add tags smb", fail}, {"remove tags ad", ok}}},
+	}
+
+	var out bytes.Buffer
+
+	result := applyBulk(&out, plans, false)
+
+	if result.failed != 2 || result.changed != 0 {
+		t.Errorf("result = %+v, want two failures", result)
+	}
+
+	if applied != 1 {
+		t.Errorf("expected the changes after a failure to be skipped, %d applied", applied)
+	}
+
+	if len(result.warnings) != 2 {
+		t.Errorf("warnings = %v, want the two warnings", result.warnings)
+	}
+
+	expected := "[1/2] A: failed: publish: boom (nothing applied)\n" +
+		"[2/2] B: failed: add tags smb: boom (applied publish)\n"
+	if out.String() != expected {
+		t.Errorf("output = %q, want %q", out.String(), expected)
+	}
+}
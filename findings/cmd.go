@@ -4,10 +4,17 @@ package findings
 
 import (
 	"errors"
+	"fmt"
+	"io"
 	"log/slog"
+	"os"
+	"path/filepath"
 	"slices"
 	"strings"
 
+	"github.com/brimstone/plextraccli/imports"
+	"github.com/brimstone/plextraccli/plextrac"
+	"github.com/brimstone/plextraccli/richtext"
 	"github.com/brimstone/plextraccli/utils"
 
 	"github.com/spf13/cobra"
@@ -28,31 +35,351 @@ func Cmd() *cobra.Command {
 	// TODO sort
 	// TODO filter by tags, or severity, or whatever
 
+	// Edit subcommand
+	editCmd := &cobra.Command{
+		Use:   "edit",
+		Short: "Edit a finding field in $EDITOR as Markdown",
+		RunE:  cmdFindingsEdit,
+	}
+	editCmd.Flags().String("field", editFields[0], "Field to edit. One of: "+strings.Join(editFields, ",")+".")
+	cmd.AddCommand(editCmd)
+
+	// Add subcommand
+	addCmd := &cobra.Command{
+		Use:   "add --writeup <title>",
+		Short: "Add a finding to a report from a writeup",
+		RunE:  cmdFindingsAdd,
+	}
+	addCmd.Flags().String("writeup", "", "Title of the writeup to create the finding from")
+	addCmd.Flags().String("title", "", "Title for the finding (default: title of writeup)")
+	addCmd.Flags().String("severity", "", "Severity for the finding (default: severity of writeup)")
+	addCmd.Flags().String("status", "", "Status for the finding (default: Open)")
+	addCmd.Flags().StringSlice("tags", nil, "Tags for the finding (default: tags of writeup)")
+	addCmd.Flags().Bool("assets", false, "Read assets to attach to the finding from stdin")
+	cmd.AddCommand(addCmd)
+
+	// Set subcommand
+	setCmd := &cobra.Command{
+		Use:   "set",
+		Short: "Set fields of a finding",
+		Long: `Set fields of a finding.
+
+Rich text fields take a file name, or - for stdin. Files are read as Markdown
+unless they end in .html or --format html is given.`,
+		RunE: cmdFindingsSet,
+	}
+	setCmd.Flags().String("title", "", "Title of the finding")
+	setCmd.Flags().String("severity", "", "Severity of the finding. One of: "+strings.Join(plextrac.Severities, ",")+".")
+	setCmd.Flags().String("status", "", "Status of the finding. One of: "+strings.Join(plextrac.Statuses, ",")+".")
+	setCmd.Flags().Bool("published", false, "Publish the finding, or --published=false to make it a draft")
+
+	for _, field := range editFields {
+		setCmd.Flags().String(field, "", "File to read the "+field+" from, - for stdin")
+	}
+
+	setCmd.Flags().String("format", "", "Format of rich text input. One of: "+strings.Join(inputFormats, ",")+". (default: by file extension)")
+	cmd.AddCommand(setCmd)
+
+	cmd.AddCommand(bulkCmd())
+	cmd.AddCommand(evidenceCmd())
+
+	// findings import came first, and is kept as an alias of import
+	importCmd := imports.Cmd()
+	importCmd.Deprecated = "it's now a top level command, use import instead"
+	cmd.AddCommand(importCmd)
+
 	return cmd
 }
 
-func cmdFindings(cmd *cobra.Command, args []string) error {
-	p, warnings, err := utils.NewPlextrac()
+var editFields = []string{
+	"description",
+	"recommendations",
+	"references",
+	"evidence",
+}
+
+var inputFormats = []string{
+	"md",
+	"html",
+}
+
+// readRichText reads a rich text field from a file, or stdin for -, and
+// converts it to HTML if it's Markdown.
+func readRichText(filename string, format string) (string, error) {
+	var (
+		data []byte
+		err  error
+	)
+
+	if filename == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(filename) //nolint:gosec
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	if format == "" {
+		format = "md"
+
+		ext := strings.ToLower(filepath.Ext(filename))
+		if ext == ".html" || ext == ".htm" {
+			format = "html"
+		}
+	}
+
+	switch format {
+	case "html":
+		return string(data), nil
+	case "md":
+		return richtext.ToHTML(string(data))
+	default:
+		return "", fmt.Errorf("unsupported format: %s", format)
+	}
+}
+
+func getFinding() (*plextrac.Finding, []error, error) {
+	_, r, warnings, err := utils.GetReport()
+	if err != nil {
+		return nil, warnings, err
+	}
+	// Get Finding
+	findingPartial := viper.GetString("finding")
+	if findingPartial == "" {
+		return nil, warnings, errors.New("must specify a finding")
+	}
+
+	f, err := r.FindingByPartial(findingPartial)
+	if err != nil {
+		return nil, warnings, err
+	}
+
+	warnings2, err := f.EnsureFull()
+	warnings = append(warnings, warnings2...)
+
+	return f, warnings, err
+}
+
+func cmdFindingsAdd(cmd *cobra.Command, args []string) error {
+	writeupTitle := cmd.Flag("writeup").Value.String()
+	if writeupTitle == "" {
+		return errors.New("must specify a writeup")
+	}
+
+	p, r, warnings, err := utils.GetReport()
 	if err != nil {
 		return err
 	}
-	// Get Client
-	clientPartial := viper.GetString("client")
-	if clientPartial == "" {
-		return errors.New("must specify a client")
+
+	writeup, err := p.WriteupByTitle(writeupTitle)
+	if err != nil {
+		return err
 	}
 
-	c, err := p.ClientByPartial(clientPartial)
+	var overrides plextrac.FindingOverrides
+
+	overrides.Title = cmd.Flag("title").Value.String()
+	overrides.Severity = cmd.Flag("severity").Value.String()
+	overrides.Status = cmd.Flag("status").Value.String()
+
+	if cmd.Flag("tags").Changed {
+		overrides.Tags, err = cmd.Flags().GetStringSlice("tags")
+		if err != nil {
+			return err
+		}
+	}
+
+	f, warnings2, err := r.AddFindingFromWriteup(writeup, overrides)
 	if err != nil {
 		return err
 	}
-	// Get Report
-	reportPartial := viper.GetString("report")
-	if reportPartial == "" {
-		return errors.New("must specify a report")
+
+	warnings = append(warnings, warnings2...)
+
+	fmt.Printf("Added finding %q from writeup %q\n", f.Name, writeup.Title)
+
+	withAssets, err := cmd.Flags().GetBool("assets")
+	if err != nil {
+		return err
+	}
+
+	if withAssets {
+		assets, err := utils.StdinToStringSlice()
+		if err != nil {
+			return err
+		}
+
+		changes, warnings2, err := f.AddAssetBulk(assets)
+		if err != nil {
+			return err
+		}
+
+		warnings = append(warnings, warnings2...)
+
+		fmt.Printf("Added %d assets, %d already present\n", len(changes.Added), len(changes.Unchanged))
 	}
 
-	r, _, err := c.ReportByPartial(reportPartial)
+	for _, warning := range warnings {
+		slog.Warn("Warning while adding finding",
+			"warning", warning,
+		)
+	}
+
+	return nil
+}
+
+func cmdFindingsSet(cmd *cobra.Command, args []string) error {
+	format := cmd.Flag("format").Value.String()
+	if format != "" && !slices.Contains(inputFormats, format) {
+		return fmt.Errorf("unsupported format: %s", format)
+	}
+
+	stdinUsers := 0
+
+	for _, field := range editFields {
+		if cmd.Flag(field).Value.String() == "-" {
+			stdinUsers++
+		}
+	}
+
+	if stdinUsers > 1 {
+		return errors.New("only one field can be read from stdin")
+	}
+
+	f, warnings, err := getFinding()
+	if err != nil {
+		return err
+	}
+
+	var (
+		update  plextrac.FindingUpdate
+		changed bool
+	)
+
+	for name, field := range map[string]**string{
+		"title":    &update.Title,
+		"severity": &update.Severity,
+		"status":   &update.Status,
+	} {
+		if cmd.Flag(name).Changed {
+			value := cmd.Flag(name).Value.String()
+			*field = &value
+			changed = true
+		}
+	}
+
+	if cmd.Flag("published").Changed {
+		published, err := cmd.Flags().GetBool("published")
+		if err != nil {
+			return err
+		}
+
+		update.Published = &published
+		changed = true
+	}
+
+	richTextFields := map[string]**string{
+		"description":     &update.Description,
+		"recommendations": &update.Recommendations,
+		"references":      &update.References,
+		"evidence":        &update.Evidence,
+	}
+
+	for _, field := range editFields {
+		filename := cmd.Flag(field).Value.String()
+		if filename == "" {
+			continue
+		}
+
+		content, err := readRichText(filename, format)
+		if err != nil {
+			return fmt.Errorf("while reading %s: %w", field, err)
+		}
+
+		*richTextFields[field] = &content
+		changed = true
+	}
+
+	if !changed {
+		return errors.New("nothing to set")
+	}
+
+	// Every field is changed in one update, so the finding isn't left half
+	// updated if one of them is bad
+	warnings2, err := f.Update(update)
+	if err != nil {
+		return err
+	}
+
+	warnings = append(warnings, warnings2...)
+
+	fmt.Printf("Updated %q\n", f.Name)
+
+	for _, warning := range warnings {
+		slog.Warn("Warning while setting finding",
+			"warning", warning,
+		)
+	}
+
+	return nil
+}
+
+func cmdFindingsEdit(cmd *cobra.Command, args []string) error {
+	field := cmd.Flag("field").Value.String()
+	if !slices.Contains(editFields, field) {
+		return fmt.Errorf("unsupported field: %s", field)
+	}
+
+	f, warnings, err := getFinding()
+	if err != nil {
+		return err
+	}
+
+	var (
+		content string
+		setter  func(string) ([]error, error)
+	)
+
+	switch field {
+	case "description":
+		content, setter = f.Description, f.SetDescription
+	case "recommendations":
+		content, setter = f.Recommendations, f.SetRecommendations
+	case "references":
+		content, setter = f.References, f.SetReferences
+	case "evidence":
+		content, setter = f.Evidence, f.SetEvidence
+	}
+
+	content, changed, err := utils.EditHTMLAsMarkdown(content, f.Name+": "+field)
+	if err != nil {
+		return err
+	}
+
+	if changed {
+		warnings2, err := setter(content)
+		if err != nil {
+			return err
+		}
+
+		warnings = append(warnings, warnings2...)
+
+		fmt.Printf("Updated %s of %q\n", field, f.Name)
+	}
+
+	for _, warning := range warnings {
+		slog.Warn("Warning while editing finding",
+			"warning", warning,
+		)
+	}
+
+	return nil
+}
+
+func cmdFindings(cmd *cobra.Command, args []string) error {
+	_, r, warnings, err := utils.GetReport()
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,395 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package findings
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/brimstone/plextraccli/plextrac"
+	"github.com/brimstone/plextraccli/redact"
+	"github.com/brimstone/plextraccli/richtext"
+	"github.com/brimstone/plextraccli/utils"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func evidenceCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "evidence",
+		Short: "Manage the evidence of a finding",
+	}
+
+	addCmd := &cobra.Command{
+		Use:   "add",
+		Short: "Add screenshots or command output to the evidence of a finding",
+		Long: `Add screenshots or command output to the evidence of a finding.
+
+Each image is uploaded to PlexTrac and appended to the evidence as a figure
+with its caption. With --dir, every image in the directory is added in
+order of file name, numbers sorting naturally, and captioned by its file
+name, so 01-responder_output.png becomes "responder output".
+
+With --stdin, command output is read from stdin and appended as a code
+block, eg: nxc smb ... | tee /dev/tty | plextraccli findings evidence add
+--stdin --caption "NetExec output". Colors are stripped, and NTLM hashes,
+passwords and other secrets are masked as the redact command would.`,
+		Args: cobra.NoArgs,
+		RunE: cmdFindingsEvidenceAdd,
+	}
+	addCmd.Flags().StringArray("image", nil, "Image to add, can be given more than once")
+	addCmd.Flags().StringArray("caption", nil, "Caption for each --image, in the same order")
+	addCmd.Flags().String("dir", "", "Directory of images to add, captioned by file name")
+	addCmd.Flags().Bool("stdin", false, "Add command output from stdin as a code block")
+	addCmd.Flags().Bool("strip-ansi", true, "Strip colors and other escape sequences from --stdin")
+	addCmd.Flags().Int("max-lines", 0, "Cut --stdin down to this many lines, keeping the start and end (default no limit)")
+	addCmd.Flags().Bool("redact", true, "Redact secrets from --stdin")
+	cmd.AddCommand(addCmd)
+
+	pullCmd := &cobra.Command{
+		Use:   "pull",
+		Short: "Download the evidence of findings along with its images",
+		Long: `Download the evidence of findings along with its images, for offline
+deliverables or archiving.
+
+The evidence of the finding, or every finding in the report if no finding
+is given, is written to --dir as finding-name.md or .html, with its images
+next to it as finding-name-01.png and so on, and pointing at them.`,
+		Args: cobra.NoArgs,
+		RunE: cmdFindingsEvidencePull,
+	}
+	pullCmd.Flags().String("dir", "evidence", "Directory to write to")
+	pullCmd.Flags().String("format", "md", "Format to write. One of: "+strings.Join(utils.PullFormats, ",")+".")
+	cmd.AddCommand(pullCmd)
+
+	return cmd
+}
+
+// captionPrefix is the sequence number screenshots are often named with to
+// keep them in order.
+var captionPrefix = regexp.MustCompile(`^\d+[\s._-]*`)
+
+// captionFromFilename makes a caption out of an image's file name.
+func captionFromFilename(filename string) string {
+	name := strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename))
+
+	if caption := captionPrefix.ReplaceAllString(name, ""); caption != "" {
+		name = caption
+	}
+
+	name = strings.NewReplacer("_", " ", "-", " ").Replace(name)
+
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// naturalLess sorts names with their runs of digits compared as numbers, so
+// shot2.png comes before shot10.png.
+func naturalLess(a string, b string) bool {
+	for a != "" && b != "" {
+		da := len(a) - len(strings.TrimLeft(a, "0123456789"))
+		db := len(b) - len(strings.TrimLeft(b, "0123456789"))
+
+		if da > 0 && db > 0 {
+			na, _ := strconv.ParseUint(a[:da], 10, 64)
+			nb, _ := strconv.ParseUint(b[:db], 10, 64)
+
+			if na != nb {
+				return na < nb
+			}
+
+			a, b = a[da:], b[db:]
+
+			continue
+		}
+
+		if a[0] != b[0] {
+			return a[0] < b[0]
+		}
+
+		a, b = a[1:], b[1:]
+	}
+
+	return len(a) < len(b)
+}
+
+// imagesInDir lists the images of the directory in natural order.
+func imagesInDir(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var images []string
+
+	for _, e := range entries {
+		if e.IsDir() || !slices.Contains(plextrac.ImageExtensions, strings.ToLower(filepath.Ext(e.Name()))) {
+			continue
+		}
+
+		images = append(images, e.Name())
+	}
+
+	slices.SortFunc(images, func(a, b string) int {
+		switch {
+		case naturalLess(strings.ToLower(a), strings.ToLower(b)):
+			return -1
+		case naturalLess(strings.ToLower(b), strings.ToLower(a)):
+			return 1
+		}
+
+		return strings.Compare(a, b)
+	})
+
+	for i, name := range images {
+		images[i] = filepath.Join(dir, name)
+	}
+
+	return images, nil
+}
+
+func cmdFindingsEvidenceAdd(cmd *cobra.Command, args []string) error {
+	filenames, err := cmd.Flags().GetStringArray("image")
+	if err != nil {
+		return err
+	}
+
+	captions, err := cmd.Flags().GetStringArray("caption")
+	if err != nil {
+		return err
+	}
+
+	dir, err := cmd.Flags().GetString("dir")
+	if err != nil {
+		return err
+	}
+
+	stdin, err := cmd.Flags().GetBool("stdin")
+	if err != nil {
+		return err
+	}
+
+	if stdin {
+		if len(filenames) > 0 || dir != "" {
+			return errors.New("can't use --stdin with --image or --dir")
+		}
+
+		if len(captions) > 1 {
+			return errors.New("only one caption for --stdin")
+		}
+
+		return addEvidenceOutput(cmd, strings.Join(captions, ""))
+	}
+
+	if len(captions) > len(filenames) {
+		return errors.New("more captions than images")
+	}
+
+	if dir != "" {
+		if len(filenames) > 0 {
+			return errors.New("can't use --image with --dir")
+		}
+
+		filenames, err = imagesInDir(dir)
+		if err != nil {
+			return err
+		}
+
+		if len(filenames) == 0 {
+			return fmt.Errorf("no images in %s", dir)
+		}
+	}
+
+	if len(filenames) == 0 {
+		return errors.New("must specify --image, --dir or --stdin")
+	}
+
+	var images []plextrac.EvidenceImage
+
+	for i, filename := range filenames {
+		data, err := os.ReadFile(filename) //nolint:gosec
+		if err != nil {
+			return err
+		}
+
+		caption := captionFromFilename(filename)
+		if i < len(captions) {
+			caption = captions[i]
+		}
+
+		images = append(images, plextrac.EvidenceImage{
+			Filename: filename,
+			Data:     data,
+			Caption:  caption,
+		})
+	}
+
+	f, warnings, err := getFinding()
+	if err != nil {
+		return err
+	}
+
+	warnings2, err := f.AddEvidenceImages(images)
+	warnings = append(warnings, warnings2...)
+
+	for _, warning := range warnings {
+		slog.Warn("Warning while adding evidence",
+			"warning", warning,
+		)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	for _, image := range images {
+		fmt.Printf("Added %s: %s\n", image.Filename, image.Caption)
+	}
+
+	return nil
+}
+
+// addEvidenceOutput adds command output from stdin to the evidence as a
+// code block.
+func addEvidenceOutput(cmd *cobra.Command, caption string) error {
+	stripANSI, err := cmd.Flags().GetBool("strip-ansi")
+	if err != nil {
+		return err
+	}
+
+	maxLines, err := cmd.Flags().GetInt("max-lines")
+	if err != nil {
+		return err
+	}
+
+	redactOutput, err := cmd.Flags().GetBool("redact")
+	if err != nil {
+		return err
+	}
+
+	data, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return err
+	}
+
+	output := string(data)
+	if strings.TrimSpace(output) == "" {
+		return errors.New("no output on stdin")
+	}
+
+	if stripANSI {
+		output = utils.StripANSI(output)
+	}
+
+	if redactOutput {
+		redactor, err := redact.Configured()
+		if err != nil {
+			return err
+		}
+
+		output, _ = redactor.Redact(output)
+	}
+
+	output = utils.Truncate(output, maxLines)
+
+	f, warnings, err := getFinding()
+	if err != nil {
+		return err
+	}
+
+	warnings2, err := f.AppendEvidence(richtext.CaptionedCodeBlock(output, caption))
+	warnings = append(warnings, warnings2...)
+
+	for _, warning := range warnings {
+		slog.Warn("Warning while adding evidence",
+			"warning", warning,
+		)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Added %d lines of output to %s\n", strings.Count(strings.TrimRight(output, "\n"), "\n")+1, f.Name)
+
+	return nil
+}
+
+func cmdFindingsEvidencePull(cmd *cobra.Command, args []string) error {
+	dir, err := cmd.Flags().GetString("dir")
+	if err != nil {
+		return err
+	}
+
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return err
+	}
+
+	if !slices.Contains(utils.PullFormats, format) {
+		return fmt.Errorf("unknown format %q, must be one of: %s", format, strings.Join(utils.PullFormats, ", "))
+	}
+
+	p, r, warnings, err := utils.GetReport()
+	if err != nil {
+		return err
+	}
+
+	var findings []*plextrac.Finding
+
+	if viper.GetString("finding") != "" {
+		f, err := r.FindingByPartial(viper.GetString("finding"))
+		if err != nil {
+			return err
+		}
+
+		findings = append(findings, f)
+	} else {
+		var warnings2 []error
+
+		findings, warnings2, err = r.Findings()
+		warnings = append(warnings, warnings2...)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	slugs := utils.Slugs{}
+
+	for _, f := range findings {
+		warnings2, err := f.EnsureFull()
+		warnings = append(warnings, warnings2...)
+
+		if err != nil {
+			return err
+		}
+
+		if f.Evidence == "" {
+			continue
+		}
+
+		filename, images, err := utils.PullRichText(p, f.Evidence, dir, slugs.Slug(f.Name), format)
+		if err != nil {
+			return fmt.Errorf("%s: %w", f.Name, err)
+		}
+
+		fmt.Printf("Wrote %s with %d images\n", filename, len(images))
+	}
+
+	for _, warning := range warnings {
+		slog.Warn("Warning while pulling evidence",
+			"warning", warning,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,258 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+// Package grammar splits prose into sentences and words, and finds the
+// style problems reviewers look for in them: gendered pronouns, first person,
+// passive voice and narration in the present tense.
+//
+// These are heuristics based on word lists rather than a parser, so they
+// find the common cases and can be wrong about the rest.
+package grammar
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// abbreviations end with a period without ending the sentence.
+var abbreviations = []string{
+	"e.g.", "i.e.", "etc.", "vs.", "mr.", "mrs.", "ms.", "dr.", "st.", "no.",
+	"inc.", "ltd.", "corp.", "co.", "approx.", "fig.", "cf.", "al.", "jr.", "sr.",
+}
+
+// Sentences splits a paragraph into sentences.
+func Sentences(paragraph string) []string {
+	var sentences []string
+
+	fields := strings.Fields(paragraph)
+	start := 0
+
+	for i, field := range fields {
+		if !endsSentence(field) {
+			continue
+		}
+
+		// The next sentence has to start like one
+		if i+1 < len(fields) {
+			next := []rune(strings.TrimLeft(fields[i+1], `"'(“‘`))
+			if len(next) == 0 || unicode.IsLower(next[0]) {
+				continue
+			}
+		}
+
+		sentences = append(sentences, strings.Join(fields[start:i+1], " "))
+		start = i + 1
+	}
+
+	if start < len(fields) {
+		sentences = append(sentences, strings.Join(fields[start:], " "))
+	}
+
+	return sentences
+}
+
+func endsSentence(field string) bool {
+	trimmed := strings.TrimRight(field, `"')”’`)
+	if !strings.HasSuffix(trimmed, ".") && !strings.HasSuffix(trimmed, "!") && !strings.HasSuffix(trimmed, "?") {
+		return false
+	}
+
+	if slices.Contains(abbreviations, strings.ToLower(trimmed)) {
+		return false
+	}
+
+	// Initials, eg: J. Smith
+	r := []rune(trimmed)
+
+	return len(r) != 2 || !unicode.IsUpper(r[0])
+}
+
+// wordPattern is a word, with apostrophes and hyphens inside it.
+var wordPattern = regexp.MustCompile(`[\p{L}\p{N}]+(?:['’-][\p{L}\p{N}]+)*`)
+
+// Words returns the words of a sentence.
+func Words(sentence string) []string {
+	return wordPattern.FindAllString(sentence, -1)
+}
+
+// gendered are the gendered pronouns, and what to use instead.
+var gendered = map[string]string{
+	"he":      "they",
+	"him":     "them",
+	"his":     "their",
+	"himself": "themselves",
+	"she":     "they",
+	"her":     "them or their",
+	"hers":    "theirs",
+	"herself": "themselves",
+}
+
+// Pronoun is a gendered pronoun, and the neutral one to use instead.
+type Pronoun struct {
+	Word    string
+	Neutral string
+}
+
+// GenderedPronouns returns the gendered pronouns in the sentence.
+func GenderedPronouns(sentence string) []Pronoun {
+	var pronouns []Pronoun
+
+	for _, w := range Words(sentence) {
+		// Pronouns in capitals are more likely to be something else
+		if len(w) > 1 && strings.ToUpper(w) == w {
+			continue
+		}
+
+		if neutral, ok := gendered[strings.ToLower(w)]; ok {
+			pronouns = append(pronouns, Pronoun{Word: w, Neutral: neutral})
+		}
+	}
+
+	return pronouns
+}
+
+// firstPerson are the first person pronouns.
+var firstPerson = []string{"i", "me", "my", "mine", "myself", "we", "us", "our", "ours", "ourselves"}
+
+// FirstPerson returns the first person pronouns in the sentence.
+func FirstPerson(sentence string) []string {
+	var pronouns []string
+
+	for _, w := range Words(sentence) {
+		// US is the country, and I the only pronoun in capitals
+		if w != "I" && len(w) > 1 && strings.ToUpper(w) == w {
+			continue
+		}
+
+		if slices.Contains(firstPerson, strings.ToLower(w)) {
+			pronouns = append(pronouns, w)
+		}
+	}
+
+	return pronouns
+}
+
+// toBe are the forms of be that make the passive voice.
+var toBe = []string{"am", "is", "are", "was", "were", "be", "been", "being"}
+
+// irregular are past participles that don't end in -ed.
+var irregular = []string{
+	"beaten", "become", "begun", "bent", "bitten", "blown", "bought", "bound",
+	"broken", "brought", "built", "caught", "chosen", "cut", "dealt", "done",
+	"drawn", "driven", "eaten", "fallen", "fed", "felt", "found", "forgotten",
+	"frozen", "given", "gotten", "grown", "heard", "held", "hidden", "hit",
+	"hung", "kept", "known", "laid", "led", "left", "lent", "let", "lost",
+	"made", "meant", "met", "overridden", "overwritten", "paid", "put", "read",
+	"rewritten", "ridden", "run", "said", "seen", "sent", "set", "shaken",
+	"shown", "shut", "sold", "sought", "spent", "split", "spoken", "spread",
+	"stolen", "struck", "stuck", "swept", "taken", "taught", "thought",
+	"thrown", "told", "torn", "undone", "understood", "withdrawn", "won",
+	"worn", "written",
+}
+
+// notParticiples end in -ed but are adjectives after be.
+var notParticiples = []string{"need", "seed", "speed", "bed", "red", "shed", "feed", "indeed"}
+
+func isParticiple(word string) bool {
+	word = strings.ToLower(word)
+
+	if slices.Contains(irregular, word) {
+		return true
+	}
+
+	return strings.HasSuffix(word, "ed") && len(word) > 4 && !slices.Contains(notParticiples, word)
+}
+
+// Passive returns the passive verb phrase of the sentence, eg: was
+// compromised, or nothing if it's active.
+func Passive(sentence string) string {
+	words := Words(sentence)
+
+	for i, w := range words {
+		if !slices.Contains(toBe, strings.ToLower(w)) {
+			continue
+		}
+
+		// Skip an adverb or not between them, eg: was quickly compromised
+		j := i + 1
+		for j < len(words) && j <= i+2 && (strings.HasSuffix(strings.ToLower(words[j]), "ly") || strings.EqualFold(words[j], "not")) {
+			j++
+		}
+
+		if j < len(words) && isParticiple(words[j]) {
+			return strings.Join(words[i:j+1], " ")
+		}
+	}
+
+	return ""
+}
+
+// narrativeVerbs are the verbs of attack narratives, which should be in the
+// past tense.
+var narrativeVerbs = []string{
+	"access", "attempt", "authenticate", "begin", "capture", "compromise",
+	"connect", "crack", "decrypt", "discover", "download", "dump", "enumerate",
+	"escalate", "execute", "exfiltrate", "exploit", "extract", "find", "gain",
+	"identify", "launch", "leverage", "log", "move", "obtain", "perform",
+	"pivot", "relay", "retrieve", "scan", "send", "spray", "start", "upload",
+	"verify",
+}
+
+// nouns are narrative verbs whose present forms are more often nouns, eg:
+// login attempts, so those aren't narration on their own.
+var nouns = []string{"attempt", "dump", "log", "pivot", "scan", "spray", "start", "upload", "download"}
+
+// presentForm is the third person present form of the verb, eg: obtains.
+func presentForm(verb string) string {
+	switch {
+	case strings.HasSuffix(verb, "s"), strings.HasSuffix(verb, "sh"), strings.HasSuffix(verb, "ch"), strings.HasSuffix(verb, "x"):
+		return verb + "es"
+	case strings.HasSuffix(verb, "y") && !strings.ContainsAny(verb[len(verb)-2:len(verb)-1], "aeiou"):
+		return verb[:len(verb)-1] + "ies"
+	}
+
+	return verb + "s"
+}
+
+// presentParticiple is the -ing form of the verb, eg: obtaining.
+func presentParticiple(verb string) string {
+	switch {
+	case verb == "begin", verb == "log", verb == "scan":
+		return verb + verb[len(verb)-1:] + "ing"
+	case strings.HasSuffix(verb, "e"):
+		return verb[:len(verb)-1] + "ing"
+	}
+
+	return verb + "ing"
+}
+
+// subjects start narration in the present tense, eg: we obtain.
+var subjects = []string{"i", "we", "they"}
+
+// PresentTense returns the narration in the present tense in the sentence,
+// eg: obtains or we obtain, or nothing if there isn't any.
+func PresentTense(sentence string) string {
+	words := Words(sentence)
+
+	for i, w := range words {
+		lower := strings.ToLower(w)
+
+		for _, verb := range narrativeVerbs {
+			if !slices.Contains(nouns, verb) && lower == presentForm(verb) {
+				return w
+			}
+
+			if i > 0 && lower == verb && slices.Contains(subjects, strings.ToLower(words[i-1])) {
+				return words[i-1] + " " + w
+			}
+
+			// Progressive, eg: is obtaining
+			if i > 0 && lower == presentParticiple(verb) && slices.Contains([]string{"is", "are", "am"}, strings.ToLower(words[i-1])) {
+				return words[i-1] + " " + w
+			}
+		}
+	}
+
+	return ""
+}
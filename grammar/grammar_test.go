@@ -0,0 +1,141 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package grammar_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/brimstone/plextraccli/grammar"
+)
+
+func TestSentences(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		paragraph string
+		expected  []string
+	}{
+		{
+			name:      "sentences",
+			paragraph: "The hosts were scanned. Were any vulnerable? Yes!",
+			expected:  []string{"The hosts were scanned.", "Were any vulnerable?", "Yes!"},
+		},
+		{
+			name:      "abbreviations",
+			paragraph: "Services, e.g. SMB and LDAP, were exposed. Dr. Smith and J. Doe agreed.",
+			expected:  []string{"Services, e.g. SMB and LDAP, were exposed.", "Dr. Smith and J. Doe agreed."},
+		},
+		{
+			name:      "addresses and files",
+			paragraph: "The host 10.0.0.1 ran ntlmrelayx.py against dc01.corp.local. It worked.",
+			expected:  []string{"The host 10.0.0.1 ran ntlmrelayx.py against dc01.corp.local.", "It worked."},
+		},
+		{
+			name:      "no period",
+			paragraph: "Relayed hashes",
+			expected:  []string{"Relayed hashes"},
+		},
+		{
+			name:      "quotes",
+			paragraph: `The password was "Summer2024!" and it worked. "Next," they said.`,
+			expected:  []string{`The password was "Summer2024!" and it worked.`, `"Next," they said.`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := grammar.Sentences(tt.paragraph)
+			if !slices.Equal(got, tt.expected) {
+				t.Errorf("Sentences() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGenderedPronouns(t *testing.T) {
+	t.Parallel()
+
+	got := grammar.GenderedPronouns("He gave his password to HER manager, and she reset it herself.")
+
+	expected := []grammar.Pronoun{
+		{Word: "He", Neutral: "they"},
+		{Word: "his", Neutral: "their"},
+		{Word: "she", Neutral: "they"},
+		{Word: "herself", Neutral: "themselves"},
+	}
+	if !slices.Equal(got, expected) {
+		t.Errorf("GenderedPronouns() = %v, want %v", got, expected)
+	}
+}
+
+func TestFirstPerson(t *testing.T) {
+	t.Parallel()
+
+	got := grammar.FirstPerson("We relayed our hashes, and I cracked them in the US.")
+	if !slices.Equal(got, []string{"We", "our", "I"}) {
+		t.Errorf("FirstPerson() = %v", got)
+	}
+
+	if got := grammar.FirstPerson("The tester relayed hashes."); len(got) != 0 {
+		t.Errorf("expected no first person, got %v", got)
+	}
+}
+
+func TestPassive(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		sentence string
+		expected string
+	}{
+		{"The domain was compromised.", "was compromised"},
+		{"Hashes were quickly cracked by the tester.", "were quickly cracked"},
+		{"Signing is not required.", "is not required"},
+		{"The credentials were stolen.", "were stolen"},
+		{"The tester compromised the domain.", ""},
+		{"The server is vulnerable.", ""},
+		{"The hosts were red.", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sentence, func(t *testing.T) {
+			t.Parallel()
+
+			if got := grammar.Passive(tt.sentence); got != tt.expected {
+				t.Errorf("Passive() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPresentTense(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		sentence string
+		expected string
+	}{
+		{"The tester obtains a hash.", "obtains"},
+		{"NetExec identifies hosts without signing.", "identifies"},
+		{"Responder relays the hash.", "relays"},
+		{"Next, we escalate to Domain Admin.", "we escalate"},
+		{"The tester is running Responder and is capturing hashes.", "is capturing"},
+		{"The tester obtained a hash.", ""},
+		{"Login attempts were logged.", ""},
+		{"Nessus scans found nothing.", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sentence, func(t *testing.T) {
+			t.Parallel()
+
+			if got := grammar.PresentTense(tt.sentence); got != tt.expected {
+				t.Errorf("PresentTense() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
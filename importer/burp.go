@@ -0,0 +1,189 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package importer
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/brimstone/plextraccli/richtext"
+)
+
+// maxResponseLength is how much of each response goes in the evidence, as
+// web scanners happily include whole pages.
+const maxResponseLength = 4096
+
+// burpImporter imports Burp Suite's XML issue export.
+type burpImporter struct{}
+
+func (burpImporter) Name() string {
+	return "burp"
+}
+
+func (burpImporter) Sniff(head []byte) bool {
+	return bytes.Contains(head, []byte("<issues")) && bytes.Contains(head, []byte("burpVersion"))
+}
+
+func (burpImporter) Parse(r io.Reader) (Result, error) {
+	findings, err := ParseBurp(r)
+
+	return Result{Findings: findings}, err
+}
+
+// webEvidence renders an instance of a web finding, a heading with where it
+// was found followed by its detail and the request and response.
+func webEvidence(location string, detail string, request string, response string) string {
+	var evidence strings.Builder
+
+	evidence.WriteString("<p><strong>")
+	evidence.WriteString(html.EscapeString(location))
+	evidence.WriteString("</strong></p>")
+	evidence.WriteString(detail)
+
+	if request != "" {
+		evidence.WriteString(richtext.CodeBlock(request))
+	}
+
+	if len(response) > maxResponseLength {
+		response = response[:maxResponseLength] + "\n[truncated]"
+	}
+
+	if response != "" {
+		evidence.WriteString(richtext.CodeBlock(response))
+	}
+
+	return evidence.String()
+}
+
+// webHost is the host of a URL, with its address if it's known.
+func webHost(u *url.URL, address string) Host {
+	host := Host{
+		Address: address,
+	}
+
+	name := strings.ToLower(u.Hostname())
+
+	switch {
+	case address == "":
+		host.Address = name
+	case name != address:
+		host.Hostnames = []string{name}
+	}
+
+	host.URLs = []string{u.String()}
+
+	return host
+}
+
+type burpIssues struct {
+	Issues []struct {
+		Type string `xml:"type"`
+		Name string `xml:"name"`
+		Host struct {
+			IP  string `xml:"ip,attr"`
+			URL string `xml:",chardata"`
+		} `xml:"host"`
+		Path                  string `xml:"path"`
+		Location              string `xml:"location"`
+		Severity              string `xml:"severity"`
+		Confidence            string `xml:"confidence"`
+		IssueBackground       string `xml:"issueBackground"`
+		RemediationBackground string `xml:"remediationBackground"`
+		References            string `xml:"references"`
+		Classifications       string `xml:"vulnerabilityClassifications"`
+		IssueDetail           string `xml:"issueDetail"`
+		RemediationDetail     string `xml:"remediationDetail"`
+		RequestResponses      []struct {
+			Request  burpMessage `xml:"request"`
+			Response burpMessage `xml:"response"`
+		} `xml:"requestresponse"`
+	} `xml:"issue"`
+}
+
+type burpMessage struct {
+	Base64 bool   `xml:"base64,attr"`
+	Value  string `xml:",chardata"`
+}
+
+func (m burpMessage) decode() (string, error) {
+	if !m.Base64 {
+		return m.Value, nil
+	}
+
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(m.Value))
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+// ParseBurp parses Burp Suite's XML issue export into one finding per issue
+// type, with every URL it was found at. The evidence has the detail of each
+// instance along with its requests and responses.
+func ParseBurp(r io.Reader) ([]Finding, error) {
+	var issues burpIssues
+
+	err := xml.NewDecoder(r).Decode(&issues)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse burp xml: %w", err)
+	}
+
+	var findings []Finding
+
+	for _, issue := range issues.Issues {
+		severity, err := MapSeverity(issue.Severity)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", issue.Name, err)
+		}
+
+		location := strings.TrimSpace(issue.Host.URL) + issue.Path
+
+		u, err := url.Parse(location)
+		if err != nil {
+			return nil, fmt.Errorf("bad url %q for %s: %w", location, issue.Name, err)
+		}
+
+		f := Finding{
+			Title:           issue.Name,
+			Severity:        severity,
+			PluginID:        issue.Type,
+			Confidence:      issue.Confidence,
+			Description:     issue.IssueBackground,
+			Recommendations: issue.RemediationBackground,
+			References:      issue.References + issue.Classifications,
+			Hosts:           []Host{webHost(u, issue.Host.IP)},
+		}
+
+		detail := issue.IssueDetail
+
+		for _, rr := range issue.RequestResponses {
+			request, err := rr.Request.decode()
+			if err != nil {
+				return nil, fmt.Errorf("bad request for %s: %w", issue.Name, err)
+			}
+
+			response, err := rr.Response.decode()
+			if err != nil {
+				return nil, fmt.Errorf("bad response for %s: %w", issue.Name, err)
+			}
+
+			f.Evidence += webEvidence(strings.TrimSpace(issue.Host.URL)+issue.Location, detail, request, response)
+			detail = ""
+		}
+
+		if len(issue.RequestResponses) == 0 {
+			f.Evidence = webEvidence(strings.TrimSpace(issue.Host.URL)+issue.Location, detail, "", "")
+		}
+
+		findings = append(findings, f)
+	}
+
+	return Merge(findings), nil
+}
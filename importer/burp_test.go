@@ -0,0 +1,74 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package importer_test
+
+import (
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/brimstone/plextraccli/importer"
+)
+
+func TestParseBurp(t *testing.T) {
+	t.Parallel()
+
+	f, err := os.Open("testdata/issues.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	findings, err := importer.ParseBurp(f)
+	if err != nil {
+		t.Fatalf("ParseBurp() returned error: %v", err)
+	}
+
+	if len(findings) != 2 {
+		t.Fatalf("expected one finding per issue type, got %d", len(findings))
+	}
+
+	xss := findings[0]
+	if xss.Title != "Cross-site scripting (reflected)" || xss.Severity != "High" || xss.PluginID != "2097920" {
+		t.Errorf("unexpected finding: %#v", xss)
+	}
+
+	if xss.Confidence != "Certain" {
+		t.Errorf("expected the most certain confidence, got %q", xss.Confidence)
+	}
+
+	if !strings.Contains(xss.References, "CWE-79") || !strings.Contains(xss.References, "portswigger.net") {
+		t.Errorf("expected references and classifications, got %q", xss.References)
+	}
+
+	expected := []string{"https://www.example.com/search", "https://www.example.com/profile"}
+	if !slices.Equal(xss.AssetSpecs(), expected) {
+		t.Errorf("AssetSpecs() = %v, want %v", xss.AssetSpecs(), expected)
+	}
+
+	for _, s := range []string{
+		"<p><strong>https://www.example.com/search [q parameter]</strong></p>",
+		"<p>The value of the name request parameter",
+		"<pre><code>GET /search?q=%3cscript%3ealert(1)%3c%2fscript%3e HTTP/1.1\nHost: www.example.com",
+		"Results for &lt;script&gt;alert(1)&lt;/script&gt;",
+		"\n[truncated]</code></pre>",
+	} {
+		if !strings.Contains(xss.Evidence, s) {
+			t.Errorf("expected %q in evidence", s)
+		}
+	}
+
+	if strings.Contains(xss.Evidence, "\r") {
+		t.Error("expected requests to use unix line endings")
+	}
+
+	cleartext := findings[1]
+	if cleartext.Hosts[0].Name() != "10.0.0.80" || cleartext.Confidence != "Tentative" {
+		t.Errorf("unexpected host or confidence: %#v", cleartext)
+	}
+
+	if !strings.Contains(cleartext.Evidence, "<pre><code>POST /login HTTP/1.1") {
+		t.Errorf("expected the plain request in the evidence, got %q", cleartext.Evidence)
+	}
+}
@@ -0,0 +1,201 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package importer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/brimstone/plextraccli/richtext"
+)
+
+// CSVFields are the fields a CSV column can be mapped to.
+var CSVFields = []string{
+	"title",
+	"severity",
+	"host",
+	"port",
+	"protocol",
+	"description",
+	"recommendations",
+	"references",
+	"pluginid",
+	"cves",
+	"cvss",
+	"evidence",
+}
+
+// DefaultCSVColumns maps fields to the columns of Nessus' CSV export, which
+// most other tools' CSV looks something like.
+var DefaultCSVColumns = map[string]string{
+	"title":           "Name",
+	"severity":        "Risk",
+	"host":            "Host",
+	"port":            "Port",
+	"protocol":        "Protocol",
+	"description":     "Description",
+	"recommendations": "Solution",
+	"references":      "See Also",
+	"pluginid":        "Plugin ID",
+	"cves":            "CVE",
+	"cvss":            "CVSS v3.0 Base Score",
+	"evidence":        "Plugin Output",
+}
+
+// CSV imports findings from a CSV file with a header, one row per instance.
+// Columns maps fields to column names, on top of DefaultCSVColumns.
+type CSV struct {
+	Columns map[string]string
+}
+
+func (CSV) Name() string {
+	return "csv"
+}
+
+func (c CSV) columns() map[string]string {
+	columns := maps.Clone(DefaultCSVColumns)
+	for k, v := range c.Columns {
+		columns[strings.ToLower(k)] = v
+	}
+
+	return columns
+}
+
+func (c CSV) Sniff(head []byte) bool {
+	line, _, _ := bytes.Cut(head, []byte("\n"))
+
+	header, err := csv.NewReader(bytes.NewReader(line)).Read()
+	if err != nil {
+		return false
+	}
+
+	return slices.ContainsFunc(header, func(h string) bool {
+		return strings.EqualFold(strings.TrimSpace(h), c.columns()["title"])
+	})
+}
+
+func (c CSV) Parse(r io.Reader) (Result, error) {
+	findings, err := c.ParseCSV(r)
+
+	return Result{Findings: findings}, err
+}
+
+// ParseCSV parses the rows of a CSV file into findings, merging the rows of
+// the same finding.
+func (c CSV) ParseCSV(r io.Reader) ([]Finding, error) {
+	columns := c.columns()
+
+	for k := range columns {
+		if !slices.Contains(CSVFields, k) {
+			return nil, fmt.Errorf("unknown csv field %q, must be one of: %s", k, strings.Join(CSVFields, ", "))
+		}
+	}
+
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read csv header: %w", err)
+	}
+
+	index := make(map[string]int)
+
+	for field, column := range columns {
+		i := slices.IndexFunc(header, func(h string) bool {
+			return strings.EqualFold(strings.TrimSpace(h), column)
+		})
+		if i != -1 {
+			index[field] = i
+		}
+	}
+
+	for _, required := range []string{"title", "severity"} {
+		if _, ok := index[required]; !ok {
+			return nil, fmt.Errorf("csv has no %q column for the %s", columns[required], required)
+		}
+	}
+
+	var findings []Finding
+
+	line := 1
+
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+
+		line++
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to read csv: %w", err)
+		}
+
+		get := func(field string) string {
+			i, ok := index[field]
+			if !ok || i >= len(record) {
+				return ""
+			}
+
+			return strings.TrimSpace(record[i])
+		}
+
+		severity, err := MapSeverity(get("severity"))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		f := Finding{
+			Title:           get("title"),
+			Severity:        severity,
+			PluginID:        get("pluginid"),
+			Description:     richtext.FromText(get("description")),
+			Recommendations: richtext.FromText(get("recommendations")),
+			References:      richtext.LinkList(strings.Fields(get("references"))),
+			CVEs:            strings.FieldsFunc(get("cves"), func(r rune) bool { return r == ',' || r == ';' || r == ' ' }),
+		}
+
+		if get("cvss") != "" {
+			f.CVSS3Score, err = strconv.ParseFloat(get("cvss"), 64)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: bad cvss score %q", line, get("cvss"))
+			}
+		}
+
+		if get("evidence") != "" {
+			location := get("host")
+			if get("port") != "" && get("port") != "0" {
+				location += ":" + get("port")
+			}
+
+			f.Evidence = webEvidence(location, richtext.CodeBlock(get("evidence")), "", "")
+		}
+
+		if get("host") != "" {
+			host := Host{Address: get("host")}
+
+			number, err := strconv.Atoi(get("port"))
+			if err == nil && number != 0 {
+				protocol := get("protocol")
+				if protocol == "" {
+					protocol = "tcp"
+				}
+
+				host.Ports = []Port{{Number: number, Protocol: strings.ToLower(protocol)}}
+			}
+
+			f.Hosts = []Host{host}
+		}
+
+		findings = append(findings, f)
+	}
+
+	return Merge(findings), nil
+}
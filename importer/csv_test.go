@@ -0,0 +1,87 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package importer_test
+
+import (
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/brimstone/plextraccli/importer"
+)
+
+func TestCSV_ParseCSV(t *testing.T) {
+	t.Parallel()
+
+	f, err := os.Open("testdata/findings.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	findings, err := importer.CSV{}.ParseCSV(f)
+	if err != nil {
+		t.Fatalf("ParseCSV() returned error: %v", err)
+	}
+
+	if len(findings) != 3 {
+		t.Fatalf("expected rows to be merged into 3 findings, got %d", len(findings))
+	}
+
+	smb := findings[0]
+	if smb.Title != "SMB Signing not required" || smb.Severity != "Medium" || smb.CVSS3Score != 5.3 {
+		t.Errorf("unexpected finding: %#v", smb)
+	}
+
+	if smb.Description != "<p>Signing is not required on the remote SMB server.</p><p>An attacker can relay.</p>" {
+		t.Errorf("unexpected description: %q", smb.Description)
+	}
+
+	if !slices.Equal(smb.AssetSpecs(), []string{"10.0.0.5:445/tcp", "10.0.0.6:445/tcp"}) {
+		t.Errorf("unexpected assets: %v", smb.AssetSpecs())
+	}
+
+	if !strings.Contains(smb.Evidence, "<strong>10.0.0.5:445</strong>") {
+		t.Errorf("expected evidence labelled with where it's from, got %q", smb.Evidence)
+	}
+
+	if findings[2].Severity != "Informational" || !slices.Equal(findings[2].AssetSpecs(), []string{"10.0.0.5"}) {
+		t.Errorf("unexpected finding: %#v", findings[2])
+	}
+}
+
+func TestCSV_columns(t *testing.T) {
+	t.Parallel()
+
+	c := importer.CSV{Columns: map[string]string{
+		"title":    "Issue",
+		"severity": "Sev",
+		"host":     "Target",
+	}}
+
+	data := "Issue,Sev,Target\nWeak TLS,moderate,web01\nWeak TLS,Moderate,web02\n"
+
+	if !c.Sniff([]byte(data)) {
+		t.Error("expected the mapped title column to be recognized")
+	}
+
+	findings, err := c.ParseCSV(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("ParseCSV() returned error: %v", err)
+	}
+
+	if len(findings) != 1 || findings[0].Severity != "Medium" || len(findings[0].Hosts) != 2 {
+		t.Errorf("unexpected findings: %#v", findings)
+	}
+
+	_, err = importer.CSV{Columns: map[string]string{"titel": "Issue"}}.ParseCSV(strings.NewReader(data))
+	if err == nil {
+		t.Error("expected an error for an unknown field")
+	}
+
+	_, err = c.ParseCSV(strings.NewReader("Name,Risk\nThing,High\n"))
+	if err == nil {
+		t.Error("expected an error without a title column")
+	}
+}
@@ -0,0 +1,295 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package importer
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/brimstone/plextraccli/plextrac"
+)
+
+// PluginIDField is the custom field findings keep the tool's identifier for
+// the kind of issue in, so later imports can find them again.
+const PluginIDField = "plugin_id"
+
+// Finding is an issue reported by a tool, grouped so each one becomes one
+// PlexTrac finding. The rich text fields are HTML.
+type Finding struct {
+	Title           string
+	Severity        string
+	Description     string
+	Recommendations string
+	References      string
+	Evidence        string
+	PluginID        string
+	Confidence      string
+	CVEs            []string
+	CVSS3Score      float64
+	CVSS3Vector     string
+	Hosts           []Host
+}
+
+// Options controls which findings get imported.
+type Options struct {
+	// MinSeverity drops findings less severe than it.
+	MinSeverity string
+	// IgnorePlugins drops findings with these plugin IDs.
+	IgnorePlugins []string
+}
+
+// severityRank is the index of the severity in plextrac.Severities, where
+// lower is more severe, or -1 if it's unknown.
+func severityRank(severity string) int {
+	return slices.IndexFunc(plextrac.Severities, func(s string) bool {
+		return strings.EqualFold(s, severity)
+	})
+}
+
+// Filter returns the findings the options allow.
+func Filter(findings []Finding, o Options) ([]Finding, error) {
+	minRank := len(plextrac.Severities) - 1
+
+	if o.MinSeverity != "" {
+		minRank = severityRank(o.MinSeverity)
+		if minRank == -1 {
+			return nil, fmt.Errorf("unknown severity %q, must be one of: %s", o.MinSeverity, strings.Join(plextrac.Severities, ", "))
+		}
+	}
+
+	var filtered []Finding
+
+	for _, f := range findings {
+		if severityRank(f.Severity) > minRank {
+			continue
+		}
+
+		if f.PluginID != "" && slices.Contains(o.IgnorePlugins, f.PluginID) {
+			continue
+		}
+
+		filtered = append(filtered, f)
+	}
+
+	return filtered, nil
+}
+
+// AssetSpecs returns the affected hosts and ports of the finding in the
+// form Finding.AddAssetBulk takes.
+func (f Finding) AssetSpecs() []string {
+	var specs []string
+	for _, h := range f.Hosts {
+		specs = append(specs, h.AssetSpecs()...)
+	}
+
+	return specs
+}
+
+// Doc returns the finding as a PlexTrac finding document for
+// Report.CreateFinding, without any affected assets.
+func (f Finding) Doc() map[string]any {
+	fields := make(map[string]any)
+
+	if f.PluginID != "" {
+		fields[PluginIDField] = map[string]any{
+			"key":        PluginIDField,
+			"label":      "Plugin ID",
+			"value":      f.PluginID,
+			"sort_order": 1,
+		}
+	}
+
+	if f.Confidence != "" {
+		fields["confidence"] = map[string]any{
+			"key":        "confidence",
+			"label":      "Confidence",
+			"value":      f.Confidence,
+			"sort_order": 2,
+		}
+	}
+
+	if f.Evidence != "" {
+		fields["evidence"] = map[string]any{
+			"key":        "evidence",
+			"label":      "Evidence",
+			"value":      f.Evidence,
+			"sort_order": 0,
+		}
+	}
+
+	doc := map[string]any{
+		"title":           f.Title,
+		"severity":        f.Severity,
+		"description":     f.Description,
+		"recommendations": f.Recommendations,
+		"references":      f.References,
+		"fields":          fields,
+		"tags":            []string{},
+		"affected_assets": map[string]any{},
+	}
+
+	if f.CVSS3Vector != "" || f.CVSS3Score != 0 {
+		doc["risk_score"] = map[string]any{
+			"CVSS3": map[string]any{
+				"overall": f.CVSS3Score,
+				"vector":  f.CVSS3Vector,
+			},
+		}
+	}
+
+	var cves []map[string]any
+
+	for _, cve := range f.CVEs {
+		parts := strings.Split(cve, "-")
+		if len(parts) != 3 {
+			continue
+		}
+
+		year, _ := strconv.Atoi(parts[1])
+		id, _ := strconv.Atoi(parts[2])
+
+		cves = append(cves, map[string]any{
+			"name": cve,
+			"year": year,
+			"id":   id,
+			"link": "https://www.cve.org/CVERecord?id=" + cve,
+		})
+	}
+
+	if len(cves) > 0 {
+		doc["common_identifiers"] = map[string]any{
+			"CVE": cves,
+		}
+	}
+
+	return doc
+}
+
+// severityNames maps what tools call severities onto PlexTrac's.
+var severityNames = map[string]string{
+	"critical":      "Critical",
+	"4":             "Critical",
+	"high":          "High",
+	"3":             "High",
+	"medium":        "Medium",
+	"moderate":      "Medium",
+	"2":             "Medium",
+	"low":           "Low",
+	"1":             "Low",
+	"informational": "Informational",
+	"information":   "Informational",
+	"info":          "Informational",
+	"none":          "Informational",
+	"0":             "Informational",
+}
+
+// MapSeverity maps a tool's severity, by name or Nessus style number, onto
+// one of plextrac.Severities.
+func MapSeverity(severity string) (string, error) {
+	s, ok := severityNames[strings.ToLower(strings.TrimSpace(severity))]
+	if !ok {
+		return "", fmt.Errorf("unknown severity %q", severity)
+	}
+
+	return s, nil
+}
+
+// Confidences are the confidences tools give findings, most confident
+// first.
+var Confidences = []string{"Certain", "Firm", "Tentative"}
+
+// key is what findings are merged by, the plugin ID if there is one.
+func (f Finding) key() string {
+	if f.PluginID != "" {
+		return f.PluginID
+	}
+
+	return strings.ToLower(f.Title)
+}
+
+// Merge combines findings of the same kind, by plugin ID or title, into one
+// with all their hosts, CVEs and evidence. The first of them provides the
+// rest, and the most certain confidence wins.
+func Merge(findings []Finding) []Finding {
+	var merged []Finding
+
+	for _, f := range findings {
+		i := slices.IndexFunc(merged, func(m Finding) bool {
+			return m.key() == f.key()
+		})
+		if i == -1 {
+			first := f
+			first.Hosts = nil
+			first.CVEs = slices.Clone(f.CVEs)
+			merged = append(merged, first)
+			i = len(merged) - 1
+		} else {
+			m := &merged[i]
+
+			for _, cve := range f.CVEs {
+				if !slices.Contains(m.CVEs, cve) {
+					m.CVEs = append(m.CVEs, cve)
+				}
+			}
+
+			m.Evidence += f.Evidence
+
+			confidence := slices.Index(Confidences, f.Confidence)
+			if confidence != -1 && (m.Confidence == "" || confidence < slices.Index(Confidences, m.Confidence)) {
+				m.Confidence = f.Confidence
+			}
+		}
+
+		for _, h := range f.Hosts {
+			merged[i].addHost(h)
+		}
+	}
+
+	return merged
+}
+
+// addHost adds the host to the finding, merging its ports and URLs with
+// what's already there for that host.
+func (f *Finding) addHost(h Host) {
+	i := slices.IndexFunc(f.Hosts, func(existing Host) bool {
+		return existing.Name() == h.Name()
+	})
+	if i == -1 {
+		f.Hosts = append(f.Hosts, Host{
+			Address:   h.Address,
+			Hostnames: h.Hostnames,
+			OS:        h.OS,
+		})
+		i = len(f.Hosts) - 1
+	}
+
+	existing := &f.Hosts[i]
+
+	for _, p := range h.Ports {
+		if !slices.ContainsFunc(existing.Ports, func(e Port) bool {
+			return e.Number == p.Number && e.Protocol == p.Protocol
+		}) {
+			existing.Ports = append(existing.Ports, p)
+		}
+	}
+
+	for _, u := range h.URLs {
+		if !slices.Contains(existing.URLs, u) {
+			existing.URLs = append(existing.URLs, u)
+		}
+	}
+}
+
+// hosts returns the hosts of the findings, merged by name.
+func hosts(findings []Finding) []Host {
+	var all Finding
+	for _, f := range findings {
+		for _, h := range f.Hosts {
+			all.addHost(h)
+		}
+	}
+
+	return all.Hosts
+}
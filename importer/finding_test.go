@@ -0,0 +1,90 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package importer_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/brimstone/plextraccli/importer"
+)
+
+func TestMapSeverity(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in       string
+		expected string
+	}{
+		{"critical", "Critical"},
+		{"4", "Critical"},
+		{" High ", "High"},
+		{"Moderate", "Medium"},
+		{"Information", "Informational"},
+		{"info", "Informational"},
+		{"None", "Informational"},
+		{"0", "Informational"},
+	}
+
+	for _, tt := range tests {
+		got, err := importer.MapSeverity(tt.in)
+		if err != nil || got != tt.expected {
+			t.Errorf("MapSeverity(%q) = %q, %v, want %q", tt.in, got, err, tt.expected)
+		}
+	}
+
+	_, err := importer.MapSeverity("severe")
+	if err == nil {
+		t.Error("expected an error for an unknown severity")
+	}
+}
+
+func TestMerge(t *testing.T) {
+	t.Parallel()
+
+	merged := importer.Merge([]importer.Finding{
+		{
+			Title:      "XSS",
+			PluginID:   "1",
+			Confidence: "Tentative",
+			CVEs:       []string{"CVE-2024-0001"},
+			Evidence:   "<p>one</p>",
+			Hosts:      []importer.Host{{Address: "web01", Ports: []importer.Port{{Number: 443, Protocol: "tcp"}}}},
+		},
+		{Title: "Other", Hosts: []importer.Host{{Address: "web02"}}},
+		{
+			Title:      "XSS again",
+			PluginID:   "1",
+			Confidence: "Firm",
+			CVEs:       []string{"CVE-2024-0001", "CVE-2024-0002"},
+			Evidence:   "<p>two</p>",
+			Hosts: []importer.Host{
+				{Address: "web01", Ports: []importer.Port{{Number: 443, Protocol: "tcp"}, {Number: 8443, Protocol: "tcp"}}},
+				{Address: "web03", URLs: []string{"https://web03/"}},
+			},
+		},
+		{Title: "other", Hosts: []importer.Host{{Address: "web02"}}},
+	})
+
+	if len(merged) != 2 {
+		t.Fatalf("expected 2 findings, got %#v", merged)
+	}
+
+	xss := merged[0]
+	if xss.Title != "XSS" || xss.Confidence != "Firm" || xss.Evidence != "<p>one</p><p>two</p>" {
+		t.Errorf("unexpected merge: %#v", xss)
+	}
+
+	if !slices.Equal(xss.CVEs, []string{"CVE-2024-0001", "CVE-2024-0002"}) {
+		t.Errorf("unexpected CVEs: %v", xss.CVEs)
+	}
+
+	expected := []string{"web01:443/tcp", "web01:8443/tcp", "https://web03/"}
+	if !slices.Equal(xss.AssetSpecs(), expected) {
+		t.Errorf("AssetSpecs() = %v, want %v", xss.AssetSpecs(), expected)
+	}
+
+	if len(merged[1].Hosts) != 1 {
+		t.Errorf("expected findings without plugin IDs to merge by title, got %#v", merged[1])
+	}
+}
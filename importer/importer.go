@@ -0,0 +1,226 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+// Package importer parses the output of other tools into hosts and findings
+// that can be added to PlexTrac. Each format is an Importer in a registry,
+// picked by name or by sniffing the start of the file.
+package importer
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/brimstone/plextraccli/plextrac"
+)
+
+// sniffLength is how much of a file importers get to sniff.
+const sniffLength = 4096
+
+// Importer parses one tool's output.
+type Importer interface {
+	// Name is what --format selects the importer by.
+	Name() string
+	// Sniff reports whether the start of a file looks like this format.
+	Sniff(head []byte) bool
+	// Parse parses a whole file.
+	Parse(r io.Reader) (Result, error)
+}
+
+// Result is what an importer found in a file. Host level scanners fill in
+// Hosts, the rest only have the hosts of each finding.
+type Result struct {
+	Findings []Finding
+	Hosts    []Host
+}
+
+var registry []Importer
+
+func init() {
+	// Most specific first, as anything could be a CSV
+	Register(nmapImporter{})
+	Register(nessusImporter{})
+	Register(burpImporter{})
+	Register(nucleiImporter{})
+	Register(CSV{})
+}
+
+// Register adds an importer to the registry, replacing any with the same
+// name. Importers are sniffed in the order they're registered.
+func Register(i Importer) {
+	idx := slices.IndexFunc(registry, func(r Importer) bool {
+		return r.Name() == i.Name()
+	})
+	if idx == -1 {
+		registry = append(registry, i)
+
+		return
+	}
+
+	registry[idx] = i
+}
+
+// Names returns the names of the registered importers.
+func Names() []string {
+	var names []string
+	for _, i := range registry {
+		names = append(names, i.Name())
+	}
+
+	return names
+}
+
+// Get returns the importer with the given name.
+func Get(name string) (Importer, error) {
+	for _, i := range registry {
+		if strings.EqualFold(i.Name(), name) {
+			return i, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown format %q, must be one of: %s", name, strings.Join(Names(), ", "))
+}
+
+// Detect returns the first importer that recognizes the start of a file.
+func Detect(head []byte) (Importer, error) {
+	for _, i := range registry {
+		if i.Sniff(head) {
+			return i, nil
+		}
+	}
+
+	return nil, errors.New("unable to detect the format, use --format")
+}
+
+// Parse parses r with the named importer, or the one sniffing finds if
+// format is empty.
+func Parse(r io.Reader, format string) (Importer, Result, error) {
+	br := bufio.NewReaderSize(r, sniffLength)
+
+	var i Importer
+
+	var err error
+
+	if format == "" {
+		head, _ := br.Peek(sniffLength)
+
+		i, err = Detect(head)
+	} else {
+		i, err = Get(format)
+	}
+
+	if err != nil {
+		return nil, Result{}, err
+	}
+
+	result, err := i.Parse(br)
+
+	return i, result, err
+}
+
+// Host is a scanned host and what was found open on it.
+type Host struct {
+	Address   string
+	Hostnames []string
+	OS        string
+	Ports     []Port
+	// URLs are the locations on the host an issue was found at, for web
+	// applications.
+	URLs []string
+}
+
+// Port is an open port of a host.
+type Port struct {
+	Number   int
+	Protocol string
+	Service  string
+	Product  string
+	Version  string
+}
+
+// Name is what the host should be called in PlexTrac, its first host name
+// if it has one, otherwise its address.
+func (h Host) Name() string {
+	if len(h.Hostnames) > 0 {
+		return h.Hostnames[0]
+	}
+
+	return h.Address
+}
+
+// InventoryAsset returns the host as an asset for the client's inventory.
+func (h Host) InventoryAsset() *plextrac.InventoryAsset {
+	a := &plextrac.InventoryAsset{
+		Asset: h.Name(),
+		IPs:   []string{h.Address},
+		OS:    h.OS,
+	}
+
+	if len(h.Hostnames) > 0 {
+		a.Hostname = h.Hostnames[0]
+	}
+
+	return a
+}
+
+// AssetSpecs returns the host's URLs, or its open ports in the
+// host:port/proto/service form, with IPv6 addresses in brackets, as
+// plextrac.ParseAssetSpec understands them, or just its name if it has
+// neither.
+func (h Host) AssetSpecs() []string {
+	if len(h.URLs) > 0 {
+		return slices.Clone(h.URLs)
+	}
+
+	if len(h.Ports) == 0 {
+		return []string{h.Name()}
+	}
+
+	var specs []string
+	for _, p := range h.Ports {
+		spec := net.JoinHostPort(h.Name(), strconv.Itoa(p.Number)) + "/" + p.Protocol
+		if p.Service != "" {
+			spec += "/" + p.Service
+		}
+
+		specs = append(specs, spec)
+	}
+
+	return specs
+}
+
+// FilterPorts returns the hosts with only the ports that have one of the
+// given numbers or services, dropping hosts left without any. Empty filters
+// match everything.
+func FilterPorts(hosts []Host, numbers []int, services []string) []Host {
+	if len(numbers) == 0 && len(services) == 0 {
+		return hosts
+	}
+
+	var filtered []Host
+
+	for _, h := range hosts {
+		ports := slices.DeleteFunc(slices.Clone(h.Ports), func(p Port) bool {
+			if slices.Contains(numbers, p.Number) {
+				return false
+			}
+
+			return !slices.ContainsFunc(services, func(s string) bool {
+				return strings.EqualFold(s, p.Service)
+			})
+		})
+
+		if len(ports) == 0 {
+			continue
+		}
+
+		h.Ports = ports
+		filtered = append(filtered, h)
+	}
+
+	return filtered
+}
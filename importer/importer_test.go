@@ -0,0 +1,77 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package importer_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/brimstone/plextraccli/importer"
+)
+
+func TestParse_detects_format(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		file     string
+		format   string
+		findings int
+		hosts    int
+	}{
+		{"testdata/scan.xml", "nmap", 0, 2},
+		{"testdata/scan.nessus", "nessus", 3, 2},
+		{"testdata/issues.xml", "burp", 2, 0},
+		{"testdata/results.jsonl", "nuclei", 3, 0},
+		{"testdata/findings.csv", "csv", 3, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := os.Open(tt.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			i, result, err := importer.Parse(f, "")
+			if err != nil {
+				t.Fatalf("Parse() returned error: %v", err)
+			}
+
+			if i.Name() != tt.format {
+				t.Errorf("detected %s, want %s", i.Name(), tt.format)
+			}
+
+			if len(result.Findings) != tt.findings || len(result.Hosts) != tt.hosts {
+				t.Errorf("got %d findings and %d hosts, want %d and %d", len(result.Findings), len(result.Hosts), tt.findings, tt.hosts)
+			}
+		})
+	}
+}
+
+func TestParse_format(t *testing.T) {
+	t.Parallel()
+
+	_, _, err := importer.Parse(strings.NewReader("hello"), "")
+	if err == nil {
+		t.Error("expected an error when nothing recognizes the file")
+	}
+
+	_, _, err = importer.Parse(strings.NewReader("hello"), "qualys")
+	if err == nil || !strings.Contains(err.Error(), "nmap, nessus, burp, nuclei, csv") {
+		t.Errorf("expected an unknown format error listing the formats, got %v", err)
+	}
+
+	// Naming the format skips sniffing
+	i, result, err := importer.Parse(strings.NewReader("Name,Risk\nThing,High\n"), "CSV")
+	if err != nil {
+		t.Fatalf("Parse() returned error: %v", err)
+	}
+
+	if i.Name() != "csv" || len(result.Findings) != 1 {
+		t.Errorf("unexpected result from %s: %#v", i.Name(), result)
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package importer
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/brimstone/plextraccli/richtext"
+)
+
+// nessusImporter imports the findings of a .nessus file, along with its
+// hosts.
+type nessusImporter struct{}
+
+func (nessusImporter) Name() string {
+	return "nessus"
+}
+
+func (nessusImporter) Sniff(head []byte) bool {
+	return bytes.Contains(head, []byte("<NessusClientData_v2"))
+}
+
+func (nessusImporter) Parse(r io.Reader) (Result, error) {
+	findings, err := ParseNessus(r)
+
+	return Result{Findings: findings, Hosts: hosts(findings)}, err
+}
+
+type nessusReport struct {
+	Hosts []struct {
+		Name       string `xml:"name,attr"`
+		Properties []struct {
+			Name  string `xml:"name,attr"`
+			Value string `xml:",chardata"`
+		} `xml:"HostProperties>tag"`
+		Items []struct {
+			Port        string   `xml:"port,attr"`
+			Service     string   `xml:"svc_name,attr"`
+			Protocol    string   `xml:"protocol,attr"`
+			Severity    string   `xml:"severity,attr"`
+			PluginID    string   `xml:"pluginID,attr"`
+			PluginName  string   `xml:"pluginName,attr"`
+			Synopsis    string   `xml:"synopsis"`
+			Description string   `xml:"description"`
+			Solution    string   `xml:"solution"`
+			SeeAlso     string   `xml:"see_also"`
+			CVEs        []string `xml:"cve"`
+			CVSS3Score  string   `xml:"cvss3_base_score"`
+			CVSS3Vector string   `xml:"cvss3_vector"`
+		} `xml:"ReportItem"`
+	} `xml:"Report>ReportHost"`
+}
+
+// ParseNessus parses a .nessus file into one finding per plugin, with every
+// host and port the plugin fired on.
+func ParseNessus(r io.Reader) ([]Finding, error) {
+	var report nessusReport
+
+	err := xml.NewDecoder(r).Decode(&report)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse nessus xml: %w", err)
+	}
+
+	var findings []Finding
+
+	for _, rh := range report.Hosts {
+		host := Host{
+			Address: rh.Name,
+		}
+
+		for _, p := range rh.Properties {
+			switch p.Name {
+			case "host-ip":
+				host.Address = p.Value
+			case "host-fqdn":
+				host.Hostnames = append(host.Hostnames, strings.ToLower(p.Value))
+			case "operating-system":
+				host.OS, _, _ = strings.Cut(p.Value, "\n")
+			}
+		}
+
+		for _, item := range rh.Items {
+			severity, err := MapSeverity(item.Severity)
+			if err != nil {
+				return nil, fmt.Errorf("plugin %s: %w", item.PluginID, err)
+			}
+
+			f := Finding{
+				Title:           item.PluginName,
+				Severity:        severity,
+				PluginID:        item.PluginID,
+				Description:     richtext.FromText(item.Synopsis) + richtext.FromText(item.Description),
+				Recommendations: richtext.FromText(item.Solution),
+				References:      richtext.LinkList(strings.Split(item.SeeAlso, "\n")),
+				CVEs:            item.CVEs,
+				CVSS3Vector:     item.CVSS3Vector,
+			}
+
+			if item.CVSS3Score != "" {
+				f.CVSS3Score, err = strconv.ParseFloat(item.CVSS3Score, 64)
+				if err != nil {
+					return nil, fmt.Errorf("bad cvss3 score %q for plugin %s: %w", item.CVSS3Score, item.PluginID, err)
+				}
+			}
+
+			instance := host
+
+			// Port 0 is about the host as a whole
+			number, _ := strconv.Atoi(item.Port)
+			if number != 0 {
+				instance.Ports = []Port{{
+					Number:   number,
+					Protocol: item.Protocol,
+					Service:  item.Service,
+				}}
+			}
+
+			f.Hosts = []Host{instance}
+			findings = append(findings, f)
+		}
+	}
+
+	return Merge(findings), nil
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package importer_test
+
+import (
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/brimstone/plextraccli/importer"
+)
+
+func parseNessusTestdata(t *testing.T) []importer.Finding {
+	t.Helper()
+
+	f, err := os.Open("testdata/scan.nessus")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	findings, err := importer.ParseNessus(f)
+	if err != nil {
+		t.Fatalf("ParseNessus() returned error: %v", err)
+	}
+
+	return findings
+}
+
+func TestParseNessus(t *testing.T) {
+	t.Parallel()
+
+	findings := parseNessusTestdata(t)
+
+	if len(findings) != 3 {
+		t.Fatalf("expected one finding per plugin, got %d", len(findings))
+	}
+
+	smb := findings[0]
+	if smb.Title != "SMB Signing not required" || smb.Severity != "Medium" || smb.PluginID != "57608" {
+		t.Errorf("unexpected finding: %#v", smb)
+	}
+
+	if !strings.HasPrefix(smb.Description, "<p>Signing is not required on the remote SMB server.</p><p>Signing") {
+		t.Errorf("expected the synopsis before the description, got %q", smb.Description)
+	}
+
+	if smb.Recommendations != "<p>Enforce message signing in the host&#39;s configuration.</p>" {
+		t.Errorf("unexpected recommendations: %q", smb.Recommendations)
+	}
+
+	if strings.Count(smb.References, "<li>") != 2 {
+		t.Errorf("expected both see also links, got %q", smb.References)
+	}
+
+	if smb.CVSS3Score != 5.3 || !strings.HasPrefix(smb.CVSS3Vector, "CVSS:3.0/AV:N") {
+		t.Errorf("unexpected cvss: %v %q", smb.CVSS3Score, smb.CVSS3Vector)
+	}
+
+	expected := []string{"dc01.corp.local:445/tcp/cifs", "10.0.0.6:445/tcp/cifs", "10.0.0.6:139/tcp/smb"}
+	if !slices.Equal(smb.AssetSpecs(), expected) {
+		t.Errorf("AssetSpecs() = %v, want %v", smb.AssetSpecs(), expected)
+	}
+
+	if smb.Hosts[0].OS != "Microsoft Windows Server 2019 Standard" {
+		t.Errorf("expected the first OS guess, got %q", smb.Hosts[0].OS)
+	}
+
+	info := findings[1]
+	if info.Severity != "Informational" || !slices.Equal(info.AssetSpecs(), []string{"dc01.corp.local"}) {
+		t.Errorf("expected port 0 to be the whole host, got %#v", info)
+	}
+
+	bluekeep := findings[2]
+	if bluekeep.Severity != "Critical" || !slices.Equal(bluekeep.CVEs, []string{"CVE-2019-0708"}) {
+		t.Errorf("unexpected finding: %#v", bluekeep)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	t.Parallel()
+
+	findings := parseNessusTestdata(t)
+
+	tests := []struct {
+		name     string
+		options  importer.Options
+		expected []string
+	}{
+		{"everything", importer.Options{}, []string{"57608", "19506", "125313"}},
+		{"threshold", importer.Options{MinSeverity: "medium"}, []string{"57608", "125313"}},
+		{"ignored", importer.Options{IgnorePlugins: []string{"57608"}}, []string{"19506", "125313"}},
+		{"both", importer.Options{MinSeverity: "High", IgnorePlugins: []string{"57608"}}, []string{"125313"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			filtered, err := importer.Filter(findings, tt.options)
+			if err != nil {
+				t.Fatalf("Filter() returned error: %v", err)
+			}
+
+			var got []string
+			for _, f := range filtered {
+				got = append(got, f.PluginID)
+			}
+
+			if !slices.Equal(got, tt.expected) {
+				t.Errorf("Filter() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+
+	_, err := importer.Filter(findings, importer.Options{MinSeverity: "Severe"})
+	if err == nil {
+		t.Error("expected an error for an unknown severity")
+	}
+}
+
+func TestFinding_Doc(t *testing.T) {
+	t.Parallel()
+
+	doc := parseNessusTestdata(t)[2].Doc()
+
+	if doc["title"] != "Microsoft RDP RCE (CVE-2019-0708) (BlueKeep) (uncredentialed check)" || doc["severity"] != "Critical" {
+		t.Errorf("unexpected doc: %#v", doc)
+	}
+
+	fields, _ := doc["fields"].(map[string]any)
+	if plugin, _ := fields[importer.PluginIDField].(map[string]any); plugin["value"] != "125313" {
+		t.Errorf("expected the plugin id in the custom field, got %#v", fields)
+	}
+
+	score, _ := doc["risk_score"].(map[string]any)
+	if cvss3, _ := score["CVSS3"].(map[string]any); cvss3["overall"] != 9.8 {
+		t.Errorf("unexpected risk score: %#v", score)
+	}
+
+	identifiers, _ := doc["common_identifiers"].(map[string]any)
+	if cves, _ := identifiers["CVE"].([]map[string]any); len(cves) != 1 || cves[0]["year"] != 2019 || cves[0]["id"] != 708 {
+		t.Errorf("unexpected cves: %#v", identifiers)
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package importer
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// nmapImporter imports the hosts of an Nmap XML scan.
+type nmapImporter struct{}
+
+func (nmapImporter) Name() string {
+	return "nmap"
+}
+
+func (nmapImporter) Sniff(head []byte) bool {
+	return bytes.Contains(head, []byte("<nmaprun"))
+}
+
+func (nmapImporter) Parse(r io.Reader) (Result, error) {
+	hosts, err := ParseNmap(r)
+
+	return Result{Hosts: hosts}, err
+}
+
+type nmapRun struct {
+	Hosts []struct {
+		Status struct {
+			State string `xml:"state,attr"`
+		} `xml:"status"`
+		Addresses []struct {
+			Addr     string `xml:"addr,attr"`
+			AddrType string `xml:"addrtype,attr"`
+		} `xml:"address"`
+		Hostnames []struct {
+			Name string `xml:"name,attr"`
+		} `xml:"hostnames>hostname"`
+		Ports []struct {
+			Protocol string `xml:"protocol,attr"`
+			PortID   string `xml:"portid,attr"`
+			State    struct {
+				State string `xml:"state,attr"`
+			} `xml:"state"`
+			Service struct {
+				Name    string `xml:"name,attr"`
+				Product string `xml:"product,attr"`
+				Version string `xml:"version,attr"`
+				Tunnel  string `xml:"tunnel,attr"`
+			} `xml:"service"`
+		} `xml:"ports>port"`
+		OSMatches []struct {
+			Name string `xml:"name,attr"`
+		} `xml:"os>osmatch"`
+	} `xml:"host"`
+}
+
+// ParseNmap parses Nmap XML output, as written by -oX, into the hosts that
+// were up and their open ports.
+func ParseNmap(r io.Reader) ([]Host, error) {
+	var run nmapRun
+
+	err := xml.NewDecoder(r).Decode(&run)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse nmap xml: %w", err)
+	}
+
+	var hosts []Host
+
+	for _, h := range run.Hosts {
+		if h.Status.State != "" && h.Status.State != "up" {
+			continue
+		}
+
+		var host Host
+
+		for _, a := range h.Addresses {
+			if a.AddrType == "ipv4" || a.AddrType == "ipv6" {
+				host.Address = a.Addr
+
+				break
+			}
+		}
+
+		if host.Address == "" {
+			continue
+		}
+
+		for _, n := range h.Hostnames {
+			name := strings.ToLower(n.Name)
+			if name != "" && !slices.Contains(host.Hostnames, name) {
+				host.Hostnames = append(host.Hostnames, name)
+			}
+		}
+
+		// The best match comes first
+		if len(h.OSMatches) > 0 {
+			host.OS = h.OSMatches[0].Name
+		}
+
+		for _, p := range h.Ports {
+			if p.State.State != "open" {
+				continue
+			}
+
+			number, err := strconv.Atoi(p.PortID)
+			if err != nil {
+				return nil, fmt.Errorf("bad port %q on %s: %w", p.PortID, host.Address, err)
+			}
+
+			service := p.Service.Name
+			if p.Service.Tunnel == "ssl" {
+				service = "ssl/" + service
+				if p.Service.Name == "http" {
+					service = "https"
+				}
+			}
+
+			host.Ports = append(host.Ports, Port{
+				Number:   number,
+				Protocol: p.Protocol,
+				Service:  service,
+				Product:  p.Service.Product,
+				Version:  p.Service.Version,
+			})
+		}
+
+		hosts = append(hosts, host)
+	}
+
+	return hosts, nil
+}
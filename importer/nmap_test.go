@@ -0,0 +1,149 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package importer_test
+
+import (
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/brimstone/plextraccli/importer"
+	"github.com/brimstone/plextraccli/plextrac"
+)
+
+func parseNmapTestdata(t *testing.T) []importer.Host {
+	t.Helper()
+
+	f, err := os.Open("testdata/scan.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	hosts, err := importer.ParseNmap(f)
+	if err != nil {
+		t.Fatalf("ParseNmap() returned error: %v", err)
+	}
+
+	return hosts
+}
+
+func TestParseNmap(t *testing.T) {
+	t.Parallel()
+
+	hosts := parseNmapTestdata(t)
+
+	if len(hosts) != 2 {
+		t.Fatalf("expected the 2 hosts that are up, got %#v", hosts)
+	}
+
+	dc01 := hosts[0]
+	if dc01.Address != "10.0.0.5" || dc01.Name() != "dc01.corp.local" {
+		t.Errorf("unexpected address or name: %#v", dc01)
+	}
+
+	if dc01.OS != "Microsoft Windows Server 2019" {
+		t.Errorf("expected the best OS match, got %q", dc01.OS)
+	}
+
+	if len(dc01.Ports) != 3 {
+		t.Fatalf("expected only the 3 open ports, got %#v", dc01.Ports)
+	}
+
+	if dc01.Ports[0] != (importer.Port{Number: 53, Protocol: "tcp", Service: "domain", Product: "Simple DNS Plus"}) {
+		t.Errorf("unexpected port: %#v", dc01.Ports[0])
+	}
+
+	web := hosts[1]
+	if web.Name() != "10.0.0.80" {
+		t.Errorf("expected a host without names to go by its address, got %q", web.Name())
+	}
+
+	if web.Ports[1].Service != "https" || web.Ports[1].Version != "1.24.0" {
+		t.Errorf("expected ssl http to be https, got %#v", web.Ports[1])
+	}
+
+	expected := []string{"10.0.0.80:80/tcp/http", "10.0.0.80:443/tcp/https", "10.0.0.80:161/udp/snmp"}
+	if !slices.Equal(web.AssetSpecs(), expected) {
+		t.Errorf("AssetSpecs() = %v, want %v", web.AssetSpecs(), expected)
+	}
+}
+
+func TestParseNmap_ipv6(t *testing.T) {
+	t.Parallel()
+
+	scan := `<nmaprun><host><status state="up"/><address addr="::1" addrtype="ipv6"/>
+<ports><port protocol="tcp" portid="443"><state state="open"/><service name="https"/></port></ports>
+</host></nmaprun>`
+
+	hosts, err := importer.ParseNmap(strings.NewReader(scan))
+	if err != nil {
+		t.Fatalf("ParseNmap() returned error: %v", err)
+	}
+
+	if len(hosts) != 1 {
+		t.Fatalf("expected 1 host, got %#v", hosts)
+	}
+
+	specs := hosts[0].AssetSpecs()
+	if !slices.Equal(specs, []string{"[::1]:443/tcp/https"}) {
+		t.Fatalf("AssetSpecs() = %v, want [[::1]:443/tcp/https]", specs)
+	}
+
+	spec, err := plextrac.ParseAssetSpec(specs[0])
+	if err != nil {
+		t.Fatalf("ParseAssetSpec() returned error: %v", err)
+	}
+
+	if spec.Value != "::1" || spec.Port == nil || spec.Port.Number != 443 {
+		t.Errorf("unexpected spec: %#v", spec)
+	}
+}
+
+func TestParseNmap_invalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := importer.ParseNmap(strings.NewReader("<nmaprun><host>"))
+	if err == nil {
+		t.Error("expected an error for truncated xml")
+	}
+}
+
+func TestFilterPorts(t *testing.T) {
+	t.Parallel()
+
+	hosts := parseNmapTestdata(t)
+
+	tests := []struct {
+		name     string
+		numbers  []int
+		services []string
+		expected []string
+	}{
+		{"no filter", nil, nil, []string{"dc01.corp.local", "10.0.0.80"}},
+		{"by port", []int{445}, nil, []string{"dc01.corp.local:445/tcp/microsoft-ds"}},
+		{"by service", nil, []string{"HTTPS", "snmp"}, []string{"10.0.0.80:443/tcp/https", "10.0.0.80:161/udp/snmp"}},
+		{"nothing matches", []int{22}, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got []string
+
+			for _, h := range importer.FilterPorts(hosts, tt.numbers, tt.services) {
+				if tt.numbers == nil && tt.services == nil {
+					got = append(got, h.Name())
+				} else {
+					got = append(got, h.AssetSpecs()...)
+				}
+			}
+
+			if !slices.Equal(got, tt.expected) {
+				t.Errorf("FilterPorts() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
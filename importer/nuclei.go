@@ -0,0 +1,178 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/brimstone/plextraccli/richtext"
+)
+
+// nucleiImporter imports Nuclei's JSON lines output, as written by -jsonl.
+type nucleiImporter struct{}
+
+func (nucleiImporter) Name() string {
+	return "nuclei"
+}
+
+func (nucleiImporter) Sniff(head []byte) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(head), []byte("{")) && bytes.Contains(head, []byte(`"template-id"`))
+}
+
+func (nucleiImporter) Parse(r io.Reader) (Result, error) {
+	findings, err := ParseNuclei(r)
+
+	return Result{Findings: findings}, err
+}
+
+type nucleiResult struct {
+	TemplateID string `json:"template-id"`
+	Info       struct {
+		Name           string `json:"name"`
+		Severity       string `json:"severity"`
+		Description    string `json:"description"`
+		Remediation    string `json:"remediation"`
+		Reference      any    `json:"reference"`
+		Classification struct {
+			CVEID       []string `json:"cve-id"`
+			CVSSScore   float64  `json:"cvss-score"`
+			CVSSMetrics string   `json:"cvss-metrics"`
+		} `json:"classification"`
+	} `json:"info"`
+	Type             string   `json:"type"`
+	Host             string   `json:"host"`
+	MatchedAt        string   `json:"matched-at"`
+	IP               string   `json:"ip"`
+	Port             string   `json:"port"`
+	Request          string   `json:"request"`
+	Response         string   `json:"response"`
+	ExtractedResults []string `json:"extracted-results"`
+}
+
+// references are a list, or a single string, depending on the template.
+func (n nucleiResult) references() []string {
+	switch r := n.Info.Reference.(type) {
+	case string:
+		return strings.Split(r, "\n")
+	case []any:
+		var refs []string
+
+		for _, ref := range r {
+			if s, ok := ref.(string); ok {
+				refs = append(refs, s)
+			}
+		}
+
+		return refs
+	}
+
+	return nil
+}
+
+// host is where the result was found, a URL for web templates and a host
+// and port for network ones.
+func (n nucleiResult) host() (Host, error) {
+	if strings.Contains(n.MatchedAt, "://") {
+		u, err := url.Parse(n.MatchedAt)
+		if err != nil {
+			return Host{}, err
+		}
+
+		return webHost(u, n.IP), nil
+	}
+
+	name, port, err := net.SplitHostPort(n.MatchedAt)
+	if err != nil {
+		name = n.MatchedAt
+	}
+
+	if port == "" {
+		port = n.Port
+	}
+
+	host := webHost(&url.URL{Host: name}, n.IP)
+	host.URLs = nil
+
+	if number, err := strconv.Atoi(port); err == nil {
+		host.Ports = []Port{{Number: number, Protocol: "tcp"}}
+	}
+
+	return host, nil
+}
+
+// ParseNuclei parses Nuclei's JSON lines output into one finding per
+// template, with everywhere it matched.
+func ParseNuclei(r io.Reader) ([]Finding, error) {
+	var findings []Finding
+
+	scanner := bufio.NewScanner(r)
+	// Responses make for long lines
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+
+	line := 0
+
+	for scanner.Scan() {
+		line++
+
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+
+		var n nucleiResult
+
+		err := json.Unmarshal(scanner.Bytes(), &n)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse nuclei result on line %d: %w", line, err)
+		}
+
+		severity, err := MapSeverity(n.Info.Severity)
+		if err != nil {
+			// Nuclei has an unknown severity of its own
+			severity = "Informational"
+		}
+
+		host, err := n.host()
+		if err != nil {
+			return nil, fmt.Errorf("bad location %q on line %d: %w", n.MatchedAt, line, err)
+		}
+
+		var cves []string
+		for _, cve := range n.Info.Classification.CVEID {
+			cves = append(cves, strings.ToUpper(cve))
+		}
+
+		detail := ""
+		if len(n.ExtractedResults) > 0 {
+			detail = richtext.CodeBlock(strings.Join(n.ExtractedResults, "\n"))
+		}
+
+		findings = append(findings, Finding{
+			Title:           n.Info.Name,
+			Severity:        severity,
+			PluginID:        n.TemplateID,
+			Description:     richtext.FromText(n.Info.Description),
+			Recommendations: richtext.FromText(n.Info.Remediation),
+			References:      richtext.LinkList(n.references()),
+			CVEs:            cves,
+			CVSS3Score:      n.Info.Classification.CVSSScore,
+			CVSS3Vector:     n.Info.Classification.CVSSMetrics,
+			Evidence:        webEvidence(n.MatchedAt, detail, n.Request, n.Response),
+			Hosts:           []Host{host},
+		})
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read nuclei results: %w", err)
+	}
+
+	return Merge(findings), nil
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package importer_test
+
+import (
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/brimstone/plextraccli/importer"
+)
+
+func TestParseNuclei(t *testing.T) {
+	t.Parallel()
+
+	f, err := os.Open("testdata/results.jsonl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	findings, err := importer.ParseNuclei(f)
+	if err != nil {
+		t.Fatalf("ParseNuclei() returned error: %v", err)
+	}
+
+	if len(findings) != 3 {
+		t.Fatalf("expected one finding per template, got %d", len(findings))
+	}
+
+	git := findings[0]
+	if git.Title != "Git Configuration - Detect" || git.Severity != "Medium" || git.PluginID != "git-config" {
+		t.Errorf("unexpected finding: %#v", git)
+	}
+
+	expected := []string{"https://www.example.com/.git/config", "https://dev.example.com/.git/config"}
+	if !slices.Equal(git.AssetSpecs(), expected) {
+		t.Errorf("AssetSpecs() = %v, want %v", git.AssetSpecs(), expected)
+	}
+
+	if strings.Count(git.Evidence, "<strong>") != 2 || !strings.Contains(git.Evidence, "<pre><code>GET /.git/config HTTP/1.1\nHost") {
+		t.Errorf("expected evidence for both matches, got %q", git.Evidence)
+	}
+
+	log4j := findings[1]
+	if log4j.Severity != "Critical" || !slices.Equal(log4j.CVEs, []string{"CVE-2021-44228"}) || log4j.CVSS3Score != 10 {
+		t.Errorf("unexpected finding: %#v", log4j)
+	}
+
+	if strings.Count(log4j.References, "<li>") != 2 {
+		t.Errorf("expected a list of references, got %q", log4j.References)
+	}
+
+	smb := findings[2]
+	if !slices.Equal(smb.AssetSpecs(), []string{"10.0.0.5:445/tcp"}) {
+		t.Errorf("expected a network match to be a host and port, got %v", smb.AssetSpecs())
+	}
+}
+
+func TestParseNuclei_invalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := importer.ParseNuclei(strings.NewReader("{\"template-id\": \"x\"}\nnot json\n"))
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("expected an error for line 2, got %v", err)
+	}
+}
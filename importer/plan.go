@@ -0,0 +1,179 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package importer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/brimstone/plextraccli/plextrac"
+)
+
+// PlanItem is what importing one finding into a report will do. It either
+// adds its assets to an Existing finding, or creates a new one, from a
+// Writeup if there is one.
+type PlanItem struct {
+	Finding  Finding
+	Existing *plextrac.Finding
+	Writeup  *plextrac.Writeup
+}
+
+// matchFinding finds the finding in the report an imported finding should
+// be merged into, by title or plugin ID.
+func matchFinding(existing []*plextrac.Finding, f Finding) *plextrac.Finding {
+	for _, e := range existing {
+		if strings.EqualFold(e.Name, f.Title) {
+			return e
+		}
+	}
+
+	if f.PluginID == "" {
+		return nil
+	}
+
+	for _, e := range existing {
+		if e.Field(PluginIDField) == f.PluginID {
+			return e
+		}
+	}
+
+	return nil
+}
+
+// matchWriteup finds the writeup in the library with the same title,
+// ignoring case. A title more than one writeup has is an error.
+func matchWriteup(library []*plextrac.Writeup, title string) (*plextrac.Writeup, error) {
+	var match *plextrac.Writeup
+
+	matches := 0
+
+	for _, w := range library {
+		if strings.EqualFold(strings.TrimSpace(w.Title), strings.TrimSpace(title)) {
+			match = w
+			matches++
+		}
+	}
+
+	if matches > 1 {
+		return nil, fmt.Errorf("%d writeups are titled %q, using the tool's text", matches, title)
+	}
+
+	return match, nil
+}
+
+// NewPlan works out what importing the findings into the report would do.
+// Findings already in the report, by title or plugin ID, get their assets
+// added. With withWriteups, new findings come from the WriteupsDB entry
+// with the same title, when there is exactly one.
+func NewPlan(ua *plextrac.UserAgent, r *plextrac.Report, findings []Finding, withWriteups bool) ([]PlanItem, []error, error) {
+	existing, warnings, err := r.Findings()
+	if err != nil {
+		return nil, warnings, err
+	}
+
+	var library []*plextrac.Writeup
+
+	if withWriteups {
+		library, err = ua.Writeups()
+		if err != nil {
+			return nil, warnings, err
+		}
+	}
+
+	var plan []PlanItem
+
+	for _, f := range findings {
+		item := PlanItem{
+			Finding:  f,
+			Existing: matchFinding(existing, f),
+		}
+
+		if item.Existing == nil && withWriteups {
+			// No writeup just means using the tool's text
+			item.Writeup, err = matchWriteup(library, f.Title)
+			if err != nil {
+				warnings = append(warnings, err)
+			}
+		}
+
+		plan = append(plan, item)
+	}
+
+	return plan, warnings, nil
+}
+
+// Action is a short description of the item for previews.
+func (p PlanItem) Action() string {
+	if p.Existing != nil {
+		return "add assets"
+	}
+
+	return "create"
+}
+
+// Source is where the text of the finding comes from.
+func (p PlanItem) Source() string {
+	switch {
+	case p.Existing != nil:
+		return "existing: " + p.Existing.Name
+	case p.Writeup != nil:
+		return "writeup: " + p.Writeup.Title
+	}
+
+	return "import"
+}
+
+// Apply carries out the item, creating the finding with the given tags if
+// needed, and adding the assets. It returns the finding in the report.
+func (p PlanItem) Apply(r *plextrac.Report, tags []string) (*plextrac.Finding, []error, error) {
+	var warnings []error
+
+	f := p.Existing
+
+	if f == nil {
+		var warnings2 []error
+
+		var err error
+
+		f, warnings2, err = p.create(r, tags)
+		warnings = append(warnings, warnings2...)
+
+		if err != nil {
+			return f, warnings, err
+		}
+	}
+
+	specs := p.Finding.AssetSpecs()
+	if len(specs) == 0 {
+		return f, warnings, nil
+	}
+
+	_, warnings2, err := f.AddAssetBulk(specs)
+	warnings = append(warnings, warnings2...)
+
+	return f, warnings, err
+}
+
+func (p PlanItem) create(r *plextrac.Report, tags []string) (*plextrac.Finding, []error, error) {
+	if p.Writeup == nil {
+		doc := p.Finding.Doc()
+		if tags != nil {
+			doc["tags"] = tags
+		}
+
+		return r.CreateFinding(doc)
+	}
+
+	f, warnings, err := r.AddFindingFromWriteup(p.Writeup, plextrac.FindingOverrides{
+		Severity: p.Finding.Severity,
+		Tags:     tags,
+	})
+	if err != nil || p.Finding.Evidence == "" {
+		return f, warnings, err
+	}
+
+	warnings2, err := f.SetEvidence(p.Finding.Evidence)
+	warnings = append(warnings, warnings2...)
+
+	return f, warnings, err
+}
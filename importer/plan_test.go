@@ -0,0 +1,256 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package importer_test
+
+import (
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/brimstone/plextraccli/importer"
+	"github.com/brimstone/plextraccli/plextrac"
+)
+
+// fakePlexTrac is a PlexTrac instance with one client and a report with an
+// existing "SMB Signing not required" finding, and a writeup for BlueKeep.
+type fakePlexTrac struct {
+	mu       sync.Mutex
+	requests map[string][]map[string]any
+}
+
+func (f *fakePlexTrac) respond(key string, body map[string]any) (any, bool) {
+	switch key {
+	case "POST /api/v2/clients":
+		return map[string]any{"status": "success", "data": []map[string]any{{"client_id": 123, "name": "Test Client", "tags": []string{}}}}, true
+	case "GET /api/v1/client/123/reports":
+		return []map[string]any{{"id": 456, "data": []any{
+			456, "Test Report", nil, "Draft", 1, []string{}, []string{}, 1700000000000, "", nil, []string{}, "template", "findings",
+		}}}, true
+	case "GET /api/v1/client/123/report/456/flaws":
+		return []map[string]any{{"id": "flaw_789", "doc_id": []string{"789"}, "data": []any{
+			789, "Medium", "SMB Signing Not Required", "Open", 0, nil, 0, nil, 0, nil, "draft", "",
+		}}}, true
+	case "GET /api/v1/client/123/report/456/flaw/789", "GET /api/v1/client/123/report/456/flaw/790":
+		return map[string]any{"title": "found", "tags": []any{}, "affected_assets": map[string]any{}, "fields": map[string]any{}}, true
+	case "PUT /api/v1/client/123/report/456/flaw/789", "PUT /api/v1/client/123/report/456/flaw/790":
+		return map[string]any{"status": "success"}, true
+	case "POST /api/v1/client/123/report/456/flaw/create":
+		return map[string]any{"status": "success", "flaw_id": 790}, true
+	case "POST /api/v2/repositories/getAllWriteupsRepositories":
+		return map[string]any{"status": "success", "data": []map[string]any{{"repositoryId": "repo1"}}}, true
+	case "POST /api/v2/repositories/repo1/getWriteups":
+		return map[string]any{"status": "success", "data": []map[string]any{
+			{"id": "w1", "title": "Microsoft RDP RCE (CVE-2019-0708) (BlueKeep) (uncredentialed check)", "severity": "Critical"},
+			{"id": "w2", "title": "Nessus Scan Information Summary", "severity": "Informational"},
+			{"id": "w3", "title": "SMB Relay", "severity": "High"},
+			{"id": "w4", "title": "smb relay", "severity": "High"},
+		}}, true
+	case "POST /api/v2/client/123/assets/compare":
+		pasted, _ := body["pastedAssets"].([]any)
+
+		var created []map[string]any
+		for _, p := range pasted {
+			created = append(created, map[string]any{"asset": p})
+		}
+
+		return map[string]any{"status": "success", "existingAssets": []any{}, "newAssets": created}, true
+	case "POST /api/v2/client/123/bulk/assets":
+		assets, _ := body["assets"].([]any)
+		for i, a := range assets {
+			m, _ := a.(map[string]any)
+			m["id"] = fmt.Sprintf("asset-%d", i)
+		}
+
+		return map[string]any{"status": "success", "assets": assets}, true
+	}
+
+	return nil, false
+}
+
+func (f *fakePlexTrac) handler(t *testing.T) http.HandlerFunc {
+	t.Helper()
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		key := r.Method + " " + r.URL.Path
+
+		var body map[string]any
+
+		data, _ := io.ReadAll(r.Body)
+		if len(data) > 0 {
+			_ = json.Unmarshal(data, &body)
+		}
+
+		f.mu.Lock()
+		f.requests[key] = append(f.requests[key], body)
+		f.mu.Unlock()
+
+		response, ok := f.respond(key, body)
+		if !ok {
+			t.Errorf("unexpected request: %s", key)
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	}
+}
+
+func (f *fakePlexTrac) requestsFor(key string) []map[string]any {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.requests[key]
+}
+
+func newFakePlexTrac(t *testing.T) (*fakePlexTrac, *plextrac.UserAgent, *plextrac.Report) {
+	t.Helper()
+
+	f := &fakePlexTrac{requests: make(map[string][]map[string]any)}
+
+	server := httptest.NewTLSServer(f.handler(t))
+	t.Cleanup(server.Close)
+
+	payload, _ := json.Marshal(map[string]any{"exp": time.Now().Add(time.Hour).Unix()})
+	token := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`)) + "." +
+		base64.RawURLEncoding.EncodeToString(payload) + ".fakesig"
+
+	ua, _, err := plextrac.New(plextrac.NewOptions{
+		InstanceURL: server.Listener.Addr().String(),
+		AuthToken:   token,
+		HTTPClient: &http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, //nolint:gosec // test server only
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("New() returned error: %v", err)
+	}
+
+	c, err := ua.ClientByPartial("test")
+	if err != nil {
+		t.Fatalf("ClientByPartial() returned error: %v", err)
+	}
+
+	r, _, err := c.ReportByPartial("test")
+	if err != nil {
+		t.Fatalf("ReportByPartial() returned error: %v", err)
+	}
+
+	return f, ua, r
+}
+
+func TestNewPlan(t *testing.T) {
+	t.Parallel()
+
+	fake, ua, r := newFakePlexTrac(t)
+
+	plan, _, err := importer.NewPlan(ua, r, parseNessusTestdata(t), true)
+	if err != nil {
+		t.Fatalf("NewPlan() returned error: %v", err)
+	}
+
+	if len(plan) != 3 {
+		t.Fatalf("expected a plan item per finding, got %d", len(plan))
+	}
+
+	if plan[0].Existing == nil || plan[0].Action() != "add assets" || plan[0].Source() != "existing: SMB Signing Not Required" {
+		t.Errorf("expected SMB signing to match the existing finding by title, got %s from %s", plan[0].Action(), plan[0].Source())
+	}
+
+	if plan[1].Existing != nil || plan[1].Writeup != nil || plan[1].Source() != "import" {
+		t.Errorf("expected scan information to be created from the import, got %s", plan[1].Source())
+	}
+
+	if plan[2].Writeup == nil || plan[2].Action() != "create" {
+		t.Errorf("expected BlueKeep to come from the writeup, got %s from %s", plan[2].Action(), plan[2].Source())
+	}
+
+	if len(fake.requestsFor("POST /api/v1/client/123/report/456/flaw/create")) != 0 {
+		t.Error("expected planning not to change anything")
+	}
+
+	// Existing findings get assets, new ones are created with the tags
+	_, _, err = plan[0].Apply(r, []string{"nessus"})
+	if err != nil {
+		t.Fatalf("Apply() returned error: %v", err)
+	}
+
+	if len(fake.requestsFor("POST /api/v1/client/123/report/456/flaw/create")) != 0 {
+		t.Error("expected the existing finding to be used")
+	}
+
+	puts := fake.requestsFor("PUT /api/v1/client/123/report/456/flaw/789")
+	if len(puts) != 1 {
+		t.Fatalf("expected the existing finding to get assets, got %d updates", len(puts))
+	}
+
+	if affected, _ := puts[0]["affected_assets"].(map[string]any); len(affected) != 2 {
+		t.Errorf("expected both hosts on the existing finding, got %#v", affected)
+	}
+
+	_, _, err = plan[1].Apply(r, []string{"nessus"})
+	if err != nil {
+		t.Fatalf("Apply() returned error: %v", err)
+	}
+
+	creates := fake.requestsFor("POST /api/v1/client/123/report/456/flaw/create")
+	if len(creates) != 1 {
+		t.Fatalf("expected a finding to be created, got %d", len(creates))
+	}
+
+	if tags, _ := creates[0]["tags"].([]any); len(tags) != 1 || tags[0] != "nessus" {
+		t.Errorf("expected the new finding to be tagged, got %#v", creates[0]["tags"])
+	}
+
+	if fields, _ := creates[0]["fields"].(map[string]any); fields[importer.PluginIDField] == nil {
+		t.Errorf("expected the plugin ID on the new finding, got %#v", creates[0]["fields"])
+	}
+}
+
+func TestNewPlan_writeups(t *testing.T) {
+	t.Parallel()
+
+	fake, ua, r := newFakePlexTrac(t)
+
+	findings := []importer.Finding{
+		{Title: "microsoft rdp rce (cve-2019-0708) (bluekeep) (uncredentialed check)"},
+		{Title: "Nessus Scan Information"},
+		{Title: "SMB Relay"},
+	}
+
+	plan, warnings, err := importer.NewPlan(ua, r, findings, true)
+	if err != nil {
+		t.Fatalf("NewPlan() returned error: %v", err)
+	}
+
+	if plan[0].Writeup == nil || plan[0].Writeup.ID != "w1" {
+		t.Errorf("expected the writeup with the same title in any case, got %s", plan[0].Source())
+	}
+
+	if plan[1].Writeup != nil {
+		t.Errorf("expected a title that's only part of a writeup's not to match, got %s", plan[1].Source())
+	}
+
+	if plan[2].Writeup != nil {
+		t.Errorf("expected an ambiguous title not to match, got %s", plan[2].Source())
+	}
+
+	if len(warnings) != 1 || !strings.Contains(warnings[0].Error(), `2 writeups are titled "SMB Relay"`) {
+		t.Errorf("expected a warning about the ambiguous title, got %v", warnings)
+	}
+
+	if n := len(fake.requestsFor("POST /api/v2/repositories/getAllWriteupsRepositories")); n != 1 {
+		t.Errorf("expected the library to be fetched once, got %d", n)
+	}
+}
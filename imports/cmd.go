@@ -0,0 +1,300 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package imports
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/brimstone/plextraccli/importer"
+	"github.com/brimstone/plextraccli/plextrac"
+	"github.com/brimstone/plextraccli/types"
+	"github.com/brimstone/plextraccli/utils"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var defaultCols = []string{"action", "kind", "name", "severity", "assets", "source"}
+
+// extensions are formats the file extension gives away.
+var extensions = map[string]string{
+	".nessus": "nessus",
+	".jsonl":  "nuclei",
+	".csv":    "csv",
+}
+
+func Cmd() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "import [file]",
+		Short: "Import tool output as findings and assets",
+		Long: `Import tool output as findings and assets, from a file or stdin if no
+file or - is given. The format is detected from the file unless --format
+is given.
+
+Each plugin, issue type or template becomes one finding, with the hosts,
+ports and URLs it was found on as assets. Findings already in the report
+with the same title or plugin ID get the assets added instead. Hosts from
+host level scanners are added to the client's asset inventory, matched to
+existing assets by name.
+
+With --writeups, new findings come from the WriteupsDB entry with the
+same title, when there is exactly one, instead of the tool's boilerplate.
+The tool's evidence is kept.
+
+Defaults for --min-severity, --ignore-plugin and --tags can be set in the
+import section of the config file as minseverity, ignoreplugins and tags.
+The columns the csv format reads are set by csvcolumns, mapping any of
+` + strings.Join(importer.CSVFields, ", ") + ` to a column name.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: cmdImport,
+	}
+	cmd.Flags().String("format", "", "Format to import, one of: "+strings.Join(importer.Names(), ", ")+" (default detected)")
+	cmd.Flags().String("min-severity", "", "Skip findings less severe than this, one of: "+strings.Join(plextrac.Severities, ", "))
+	cmd.Flags().StringSlice("ignore-plugin", nil, "Skip findings from these plugin IDs")
+	cmd.Flags().StringSlice("tags", nil, "Tags for new findings")
+	cmd.Flags().Bool("writeups", false, "Create findings from matching writeups")
+	cmd.Flags().Bool("preview", false, "Show what would change without changing it")
+	cmd.Flags().String("cols", strings.Join(defaultCols, ","), "Columns to show in the preview")
+
+	return cmd
+}
+
+func importConfig(cmd *cobra.Command) (types.ImportConfig, error) {
+	var cfg types.ImportConfig
+
+	err := viper.UnmarshalKey("import", &cfg)
+	if err != nil {
+		return cfg, fmt.Errorf("error reading import config: %w", err)
+	}
+
+	if cmd.Flag("min-severity").Changed {
+		cfg.MinSeverity = cmd.Flag("min-severity").Value.String()
+	}
+
+	if cmd.Flag("ignore-plugin").Changed {
+		cfg.IgnorePlugins, err = cmd.Flags().GetStringSlice("ignore-plugin")
+		if err != nil {
+			return cfg, err
+		}
+	}
+
+	if cmd.Flag("tags").Changed {
+		cfg.Tags, err = cmd.Flags().GetStringSlice("tags")
+		if err != nil {
+			return cfg, err
+		}
+	}
+
+	return cfg, nil
+}
+
+// parseFile parses the file, or stdin for -, with the importer for format,
+// the file's extension or whatever sniffing finds.
+func parseFile(filename string, format string) (importer.Importer, importer.Result, error) {
+	if format == "" {
+		format = extensions[strings.ToLower(filepath.Ext(filename))]
+	}
+
+	in := os.Stdin
+
+	if filename != "-" {
+		var err error
+
+		in, err = os.Open(filename)
+		if err != nil {
+			return nil, importer.Result{}, err
+		}
+		defer in.Close()
+	}
+
+	return importer.Parse(in, format)
+}
+
+func cmdImport(cmd *cobra.Command, args []string) error {
+	preview, err := cmd.Flags().GetBool("preview")
+	if err != nil {
+		return err
+	}
+
+	withWriteups, err := cmd.Flags().GetBool("writeups")
+	if err != nil {
+		return err
+	}
+
+	cfg, err := importConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	if cfg.CSVColumns != nil {
+		importer.Register(importer.CSV{Columns: cfg.CSVColumns})
+	}
+
+	filename := "-"
+	if len(args) == 1 {
+		filename = args[0]
+	}
+
+	i, result, err := parseFile(filename, cmd.Flag("format").Value.String())
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Imported", "format", i.Name(), "findings", len(result.Findings), "hosts", len(result.Hosts))
+
+	result.Findings, err = importer.Filter(result.Findings, importer.Options{
+		MinSeverity:   cfg.MinSeverity,
+		IgnorePlugins: cfg.IgnorePlugins,
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(result.Findings) == 0 && len(result.Hosts) == 0 {
+		return errors.New("nothing to import")
+	}
+
+	p, c, warnings, err := utils.GetClient()
+	if err != nil {
+		return err
+	}
+
+	var r *plextrac.Report
+
+	var plan []importer.PlanItem
+
+	if len(result.Findings) > 0 {
+		var warnings2 []error
+
+		r, warnings2, err = utils.ClientReport(c)
+		warnings = append(warnings, warnings2...)
+
+		if err != nil {
+			return err
+		}
+
+		plan, warnings2, err = importer.NewPlan(p, r, result.Findings, withWriteups)
+		warnings = append(warnings, warnings2...)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	var inventory []*plextrac.InventoryAsset
+	for _, h := range result.Hosts {
+		inventory = append(inventory, h.InventoryAsset())
+	}
+
+	if preview {
+		changes, warnings2, err := c.AddAssets(inventory, true)
+		warnings = append(warnings, warnings2...)
+
+		if err != nil {
+			return err
+		}
+
+		logWarnings(warnings)
+		showPreview(cmd, plan, changes)
+
+		return nil
+	}
+
+	if len(inventory) > 0 {
+		changes, warnings2, err := c.AddAssets(inventory, false)
+		warnings = append(warnings, warnings2...)
+
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Created %d assets, updated %d, %d already present\n", len(changes.Created), len(changes.Updated), len(changes.Unchanged))
+	}
+
+	created, merged := 0, 0
+
+	for _, item := range plan {
+		f, warnings2, err := item.Apply(r, cfg.Tags)
+		warnings = append(warnings, warnings2...)
+
+		if err != nil {
+			logWarnings(warnings)
+
+			return fmt.Errorf("%s: %w", item.Finding.Title, err)
+		}
+
+		if item.Existing == nil {
+			fmt.Printf("+ %s (%s, %d assets)\n", f.Name, item.Finding.Severity, len(item.Finding.AssetSpecs()))
+
+			created++
+		} else {
+			fmt.Printf("~ %s (%d assets)\n", f.Name, len(item.Finding.AssetSpecs()))
+
+			merged++
+		}
+	}
+
+	logWarnings(warnings)
+
+	if len(plan) > 0 {
+		fmt.Printf("Created %d findings, added assets to %d existing\n", created, merged)
+	}
+
+	return nil
+}
+
+func logWarnings(warnings []error) {
+	for _, warning := range warnings {
+		slog.Warn("Warning while importing",
+			"warning", warning,
+		)
+	}
+}
+
+func showPreview(cmd *cobra.Command, plan []importer.PlanItem, changes plextrac.InventoryChanges) {
+	showCols := utils.AggregateCols(defaultCols, cmd.Flag("cols").Value.String())
+
+	var rows [][]string
+
+	for _, item := range plan {
+		rows = append(rows, []string{
+			item.Action(),
+			"finding",
+			item.Finding.Title,
+			item.Finding.Severity,
+			strconv.Itoa(len(item.Finding.AssetSpecs())),
+			item.Source(),
+		})
+	}
+
+	for _, a := range changes.Created {
+		rows = append(rows, []string{"create", "asset", a.Asset, "", "", "inventory"})
+	}
+
+	for _, a := range changes.Updated {
+		rows = append(rows, []string{"update", "asset", a.Asset, "", "", "inventory"})
+	}
+
+	utils.ShowTable(
+		[]string{
+			"Action",
+			"Kind",
+			"Name",
+			"Severity",
+			"Assets",
+			"Source",
+		},
+		rows,
+		showCols,
+	)
+
+	if len(changes.Unchanged) > 0 {
+		fmt.Printf("%d assets already in the inventory\n", len(changes.Unchanged))
+	}
+}
@@ -6,11 +6,15 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
-	"regexp"
+	"os"
+	"path/filepath"
 	"slices"
 	"strings"
 
 	"github.com/brimstone/plextraccli/plextrac"
+	"github.com/brimstone/plextraccli/redact"
+	"github.com/brimstone/plextraccli/richtext"
+	"github.com/brimstone/plextraccli/spell"
 	"github.com/brimstone/plextraccli/types"
 	"github.com/brimstone/plextraccli/utils"
 
@@ -18,274 +22,494 @@ import (
 	"github.com/spf13/viper"
 )
 
-var toolNames = []string{"MitM6", "NTLMRelayX", "Certipy", "NetExec"}
-
 func Cmd() *cobra.Command {
 	var cmd = &cobra.Command{
 		Use:   "lint",
 		Short: "Check reports and findings for style errors",
-		Long:  `Check reports and findings for style errors.`,
-		RunE:  cmdLint,
+		Long: `Check reports and findings for style errors.
+
+Each rule has an ID, such as PT001, and a name, such as missing-stop-date,
+either of which can be used to configure it. Rules are disabled, enabled if
+they're off by default, and re-leveled in the config:
+
+  lint:
+    disable: [contraction]
+    severity:
+      report-draft: error
+      PT207: off
+
+Custom rules check the prose of narratives, and the evidence, descriptions
+and recommendations of findings, for a team's own style. They're regex rules,
+which flag what matches the pattern, banned or required phrases, or case
+rules, which flag and fix phrases spelled with the wrong case. Tool names
+spelled with the wrong case are flagged already, and more are added with
+toolnames:
+
+  lint:
+    toolnames: [BloodHound, Rubeus]
+    rules:
+      - id: ACME001
+        name: active-directory
+        type: banned
+        phrases: [AD]
+        message: spell out Active Directory
+      - id: ACME002
+        type: regex
+        pattern: '\bI\b'
+        message: write in the third person
+        severity: error
+        scopes: [sections, descriptions]
+      - id: ACME003
+        type: required
+        phrases: [in scope]
+        scopes: [sections]
+        titles: [Scope]
+      - id: ACME004
+        type: case
+        phrases: [ACME Corp]
+
+Rules are suppressed for a report or finding by tagging it lint-ignore, or
+lint-ignore-<rule> for just one, eg: lint-ignore-no-screenshot. In rich
+text, an HTML comment does the same for its section or finding:
+<!-- lint-ignore --> or <!-- lint-ignore PT101, no-screenshot -->
+
+Results are written as text, JSON or SARIF, and lint exits non-zero when
+there are issues at or above --fail-on, so it can gate delivery in CI.
+
+Spelling is checked offline against English words, security terms and a
+team dictionary of tool names, protocols and acronyms. The team dictionary
+is ~/.plextrac-dictionary.txt, or the file set as lint.dictionary, with one
+word per line, and lint dict add grows it. Words can also be listed in
+lint.words. Writeups in the content library are checked with lint writeups.
+
+Grammar rules flag gendered pronouns, the first person, too much passive
+voice and long sentences in narratives and findings, and narration in the
+present tense in attack narratives. They're configured by the kind of
+narrative, matching titles by globs, with findings: true for the text of
+findings. The first profile that matches is used:
+
+  lint:
+    grammar:
+      - sections: ["*narrative*"]
+        tense: past
+        maxpassive: 0.4
+      - sections: ["executive summary"]
+        maxwords: 30
+        disable: [first-person]
+      - findings: true
+        disable: [passive-voice]
+
+Severities of findings and writeups are checked against the rating of their
+CVSS v3 base score, and findings created from a writeup against its
+severity. That gets every writeup, so disable writeup-severity if it's slow.
+
+With --fix, mechanical issues such as contractions, tool name case and
+periods at the end of captions are fixed in narratives and evidence first.
+The change to each is shown as a diff and saved once confirmed, or right
+away with --yes.`,
+		RunE: cmdLint,
+	}
+	cmd.PersistentFlags().String("format", "text", "Format to write results in. One of: "+strings.Join(Formats, ",")+".")
+	cmd.PersistentFlags().String("fail-on", "error", "Exit non-zero when there are issues of at least this severity, or none to never")
+	cmd.Flags().Bool("fix", false, "Fix what can be fixed safely before linting")
+	cmd.Flags().BoolP("yes", "y", false, "Save fixes without asking")
+
+	rulesCmd := &cobra.Command{
+		Use:   "rules",
+		Short: "List the lint rules",
+		Args:  cobra.NoArgs,
+		RunE:  cmdLintRules,
+	}
+	cmd.AddCommand(rulesCmd)
+
+	writeupsCmd := &cobra.Command{
+		Use:   "writeups",
+		Short: "Check writeups in the content library",
+		Args:  cobra.NoArgs,
+		RunE:  cmdLintWriteups,
+	}
+	writeupsCmd.Flags().String("writeup", "", "Title of the writeup to check, instead of all of them")
+	cmd.AddCommand(writeupsCmd)
+
+	dictCmd := &cobra.Command{
+		Use:   "dict",
+		Short: "List the words in the team dictionary",
+		Args:  cobra.NoArgs,
+		RunE:  cmdLintDict,
 	}
+	cmd.AddCommand(dictCmd)
+
+	dictCmd.AddCommand(&cobra.Command{
+		Use:   "add <word>...",
+		Short: "Add words to the team dictionary",
+		Args:  cobra.MinimumNArgs(1),
+		RunE:  cmdLintDictAdd,
+	})
 
 	return cmd
 }
 
-func lintReport(report *plextrac.Report) []error {
-	var errs []error
-	// General Details
-	if report.StartDate.IsZero() {
-		errs = append(errs, errors.New("report is missing Start Date"))
-	}
+// lintConfig reads the lint config, with the team dictionary in the home
+// directory unless it names one.
+func lintConfig() (types.LintConfig, error) {
+	var lintCfg types.LintConfig
 
-	if report.StopDate.IsZero() {
-		errs = append(errs, errors.New("report is missing Stop Date"))
+	err := viper.UnmarshalKey("lint", &lintCfg)
+	if err != nil {
+		return lintCfg, fmt.Errorf("error reading lint config: %w", err)
 	}
 
-	if report.StartDate.After(report.StopDate) {
-		errs = append(errs, errors.New("start date is after Stop date"))
-	}
+	if lintCfg.Dictionary == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return lintCfg, err
+		}
 
-	if report.Status == "Draft" {
-		errs = append(errs, errors.New("report is still in draft"))
+		lintCfg.Dictionary = filepath.Join(home, spell.DefaultFile)
 	}
 
-	if len(report.Tags()) == 0 {
-		errs = append(errs, errors.New("report has no tags"))
+	return lintCfg, nil
+}
+
+// newLinter sets up a linter by the lint and redact config.
+func newLinter() (*Linter, error) {
+	lintCfg, err := lintConfig()
+	if err != nil {
+		return nil, err
 	}
 
-	// Sections
-	sections, warnings, err := report.Sections()
+	redactor, err := redact.Configured()
 	if err != nil {
-		errs = append(errs, fmt.Errorf("error parsing sections: %w", err))
+		return nil, err
 	}
 
-	for _, w := range warnings {
-		errs = append(errs, fmt.Errorf("warning parsing sections: %w", w))
+	return NewLinter(lintCfg, redactor)
+}
+
+func cmdLintRules(cmd *cobra.Command, args []string) error {
+	l, err := newLinter()
+	if err != nil {
+		return err
 	}
 
-	var titles []string
-	for _, s := range sections {
-		titles = append(titles, s.Title)
+	var rows [][]string
+
+	for _, r := range l.Rules() {
+		severity, enabled := l.Enabled(r)
+
+		level := "off"
+		if enabled {
+			level = severity.String()
+		}
+
+		rows = append(rows, []string{
+			r.ID,
+			r.Name,
+			string(r.Scope),
+			level,
+			r.Description,
+		})
 	}
 
-	var lintCfg types.LintConfig
+	utils.ShowTable(
+		[]string{
+			"ID",
+			"Name",
+			"Scope",
+			"Severity",
+			"Description",
+		},
+		rows,
+		[]string{"id", "name", "scope", "severity", "description"},
+	)
+
+	return nil
+}
 
-	err = viper.UnmarshalKey("lint", &lintCfg)
+// outputFlags reads the format to write issues in, and the severity to fail
+// on, or -1 to never fail.
+func outputFlags(cmd *cobra.Command) (string, Severity, error) {
+	format, err := cmd.Flags().GetString("format")
 	if err != nil {
-		errs = append(errs, fmt.Errorf("error reading lint config: %w", err))
+		return "", 0, err
 	}
 
-	knownTags := make(map[string]bool)
+	if !slices.Contains(Formats, format) {
+		return "", 0, fmt.Errorf("unknown format %q, must be one of: %s", format, strings.Join(Formats, ", "))
+	}
 
-	for _, rs := range lintCfg.RequiredSections {
-		for _, tag := range rs.Tags {
-			knownTags[tag] = true
-		}
+	failOnName, err := cmd.Flags().GetString("fail-on")
+	if err != nil {
+		return "", 0, err
 	}
 
-	for _, t := range report.Tags() {
-		if !knownTags[t] {
-			errs = append(errs, fmt.Errorf("report uses unknown tag: %s", t))
-		}
+	if failOnName == "none" {
+		return format, -1, nil
 	}
 
-	var requiredSections []string
+	failOn, err := ParseSeverity(failOnName)
 
-	for _, rs := range lintCfg.RequiredSections {
-		if len(rs.Tags) == 0 {
-			requiredSections = append(requiredSections, rs.Section)
-		} else {
-			for _, t := range report.Tags() {
-				for _, rt := range rs.Tags {
-					if t == rt {
-						requiredSections = append(requiredSections, rs.Section)
-					}
-				}
-			}
-		}
+	return format, failOn, err
+}
+
+// writeIssues writes the issues, and fails if any are at or above failOn.
+func writeIssues(cmd *cobra.Command, format string, failOn Severity, issues []Issue) error {
+	err := Write(os.Stdout, format, issues)
+	if err != nil {
+		return err
 	}
 
-	for _, s := range requiredSections {
-		j := -1
+	if failOn < 0 {
+		return nil
+	}
 
-		for i, t := range titles {
-			if t == s {
-				j = i
-			}
-		}
+	failing := AtLeast(issues, failOn)
+	if len(failing) > 0 {
+		// The issues say what's wrong, not how to use lint
+		cmd.SilenceUsage = true
 
-		if j == -1 {
-			errs = append(errs, fmt.Errorf("missing section: %s", s))
-		} else {
-			titles = append(titles[:j], titles[j+1:]...)
-		}
+		return fmt.Errorf("%d issues at or above %s", len(failing), failOn)
 	}
 
-	for _, t := range titles {
-		errs = append(errs, fmt.Errorf("extra section: %s", t))
+	return nil
+}
+
+func cmdLint(cmd *cobra.Command, args []string) error {
+	format, failOn, err := outputFlags(cmd)
+	if err != nil {
+		return err
 	}
 
-	re := regexp.MustCompile(`.{0,10}n't.{0,10}`)
+	l, err := newLinter()
+	if err != nil {
+		return err
+	}
 
-	for _, s := range sections {
-		if slices.Contains(titles, s.Title) {
-			continue
-		}
+	fix, err := cmd.Flags().GetBool("fix")
+	if err != nil {
+		return err
+	}
 
-		matches := re.FindAllString(s.Content, -1)
-		for _, m := range matches {
-			errs = append(errs, fmt.Errorf("found contraction in section %q: %q", s.Title, m))
-		}
+	yes, err := cmd.Flags().GetBool("yes")
+	if err != nil {
+		return err
+	}
 
-		// Check for periods at the end of captions
-		if strings.Index(s.Content, ".</figcaption>") > 0 {
-			errs = append(errs, fmt.Errorf("narrative %s has at least one caption ending with a period", s.Title))
-		}
+	p, r, warnings, err := utils.GetReport()
+	if err != nil {
+		return err
+	}
 
-		for _, err := range checkCapitalization(s.Content, toolNames) {
-			errs = append(errs, fmt.Errorf("narrative %q has a misspelling: %w", s.Title, err))
+	// Findings are only checked against the writeups they were created from
+	// if the rule is enabled, as getting every writeup is slow
+	if slices.ContainsFunc(l.rules, func(rule *Rule) bool { return rule.Is("writeup-severity") }) {
+		l.Library, err = p.Writeups()
+		if err != nil {
+			return err
 		}
 	}
 
-	// TODO Check for required custom fields
+	// Get Findings
+	findings, warnings2, err := r.Findings()
+	if err != nil {
+		return err
+	}
 
-	return errs
-}
+	warnings = append(warnings, warnings2...)
 
-func lintFindings(findings []*plextrac.Finding) []error {
-	var errs []error
+	if fix {
+		warnings2, err = applyFixes(l, r, findings, yes)
+		warnings = append(warnings, warnings2...)
 
-	for _, f := range findings {
-		// TODO check for past tense
-		// Maybe https://github.com/neurosnap/sentences + https://github.com/jdkato/prose
-		// TODO check for heading 3, 2 and 1 cuz those look bad
-		// TODO check that there is at least one tag set
-		// TODO check that the report has those tags too
-		// TODO check the finding is published
-		// TODO check that the finding has a description
-		assets, warnings, err := f.Assets()
 		if err != nil {
-			return []error{err}
-		}
-
-		for _, warning := range warnings {
-			slog.Warn("Warning while creating plextrac instance",
-				"warning", warning,
-			)
-		}
-		// Lint: Check all findings have assets
-		if len(assets) == 0 {
-			errs = append(errs, fmt.Errorf("finding %q has no assets", f.Name))
-		}
-		// Lint: Check all findings have evidence
-		// fmt.Printf("Evidence: %#v\n", f.Evidence)
-		if f.Evidence == "" {
-			errs = append(errs, fmt.Errorf("finding %q has no evidence", f.Name))
-		} else {
-			if !strings.Contains(f.Evidence, "<figure") {
-				errs = append(errs, fmt.Errorf("finding %q has no screenshot for evidence", f.Name))
-			}
+			return err
 		}
+	}
 
-		if strings.Index(f.Evidence, ".</figcaption>") > 0 {
-			errs = append(errs, fmt.Errorf("finding %q has at least one caption ending with a period", f.Name))
-		}
+	issues := l.Report(r)
+	issues = append(issues, l.Findings(r, findings)...)
 
-		for _, err := range checkCapitalization(f.Evidence, toolNames) {
-			errs = append(errs, fmt.Errorf("finding %q has a misspelling: %w", f.Name, err))
-		}
+	warnings = append(warnings, l.Warnings()...)
 
-		if !f.Published {
-			errs = append(errs, fmt.Errorf("finding %q is not published", f.Name))
-		}
+	for _, warning := range warnings {
+		slog.Warn("Warning while linting",
+			"warning", warning,
+		)
 	}
 
-	return errs
+	return writeIssues(cmd, format, failOn, issues)
 }
 
-func checkCapitalization(content string, words []string) []error {
-	var errs []error
+func cmdLintWriteups(cmd *cobra.Command, args []string) error {
+	format, failOn, err := outputFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	title, err := cmd.Flags().GetString("writeup")
+	if err != nil {
+		return err
+	}
 
-	for _, word := range words {
-		escapedWord := regexp.QuoteMeta(word)
-		pattern := `(?i)\b` + escapedWord + `\b` // Case-insensitive whole-word match
+	l, err := newLinter()
+	if err != nil {
+		return err
+	}
 
-		regex, err := regexp.Compile(pattern)
-		if err != nil {
-			errs = append(errs, err)
+	p, warnings, err := utils.NewPlextrac()
+	if err != nil {
+		return err
+	}
 
-			continue
-		}
+	writeups, err := p.Writeups()
+	if err != nil {
+		return err
+	}
 
-		for _, match := range regex.FindStringSubmatch(content) {
-			// Check if the exact (original) case of the word is present
-			if match != word {
-				errs = append(errs, fmt.Errorf("%q has the wrong case", word))
-			}
+	if title != "" {
+		writeups = slices.DeleteFunc(writeups, func(w *plextrac.Writeup) bool { return w.Title != title })
+		if len(writeups) == 0 {
+			return errors.New("writeup not found")
 		}
 	}
 
-	return errs
+	issues := l.Writeups(writeups)
+
+	warnings = append(warnings, l.Warnings()...)
+
+	for _, warning := range warnings {
+		slog.Warn("Warning while linting",
+			"warning", warning,
+		)
+	}
+
+	return writeIssues(cmd, format, failOn, issues)
 }
 
-func cmdLint(cmd *cobra.Command, args []string) error {
-	p, warnings, err := utils.NewPlextrac()
+func cmdLintDict(cmd *cobra.Command, args []string) error {
+	cfg, err := lintConfig()
 	if err != nil {
 		return err
 	}
-	// Get Client
-	clientPartial := viper.GetString("client")
-	if clientPartial == "" {
-		return errors.New("must specify a client")
-	}
 
-	c, err := p.ClientByPartial(clientPartial)
+	words, err := spell.ReadFile(cfg.Dictionary)
 	if err != nil {
 		return err
 	}
-	// Get Report
-	reportPartial := viper.GetString("report")
-	if reportPartial == "" {
-		return errors.New("must specify a report")
+
+	for _, w := range slices.Concat(words, cfg.Words) {
+		fmt.Println(w)
 	}
 
-	r, warnings2, err := c.ReportByPartial(reportPartial)
+	return nil
+}
+
+func cmdLintDictAdd(cmd *cobra.Command, args []string) error {
+	cfg, err := lintConfig()
 	if err != nil {
 		return err
 	}
 
-	warnings = append(warnings, warnings2...)
+	added, err := spell.AddToFile(cfg.Dictionary, args...)
+	if err != nil {
+		return err
+	}
 
-	errors := lintReport(r)
-	if len(errors) > 0 {
-		fmt.Printf("Errors with report:\n")
+	for _, w := range added {
+		fmt.Printf("Added %s to %s\n", w, cfg.Dictionary)
+	}
 
-		for _, err := range errors {
-			fmt.Printf("- %s\n", err)
-		}
+	return nil
+}
+
+// confirmFix shows the diff of a fix, as Markdown since that's easier to
+// read than HTML, and asks whether to save it.
+func confirmFix(name string, before string, after string, rules []*Rule, yes bool) (bool, error) {
+	beforeMD, err := richtext.ToMarkdown(before)
+	if err != nil {
+		beforeMD = before
 	}
 
-	// Get Findings
-	findings, _, err := r.Findings()
+	afterMD, err := richtext.ToMarkdown(after)
 	if err != nil {
-		return err
+		afterMD = after
 	}
 
-	errors = lintFindings(findings)
-	if len(errors) > 0 {
-		fmt.Printf("Errors with findings:\n")
+	var fixed []string
+	for _, r := range rules {
+		fixed = append(fixed, r.String())
+	}
+
+	fmt.Fprintf(os.Stderr, "Fixing %s: %s\n", name, strings.Join(fixed, ", "))
+	fmt.Fprint(os.Stderr, utils.UnifiedDiff(beforeMD+"\n", afterMD+"\n", name, name))
+
+	if yes {
+		return true, nil
+	}
+
+	return utils.Confirm("Save fixes to " + name + "?")
+}
 
-		for _, err := range errors {
-			fmt.Printf("- %s\n", err)
+// applyFixes fixes the narratives and evidence of the report, saving each
+// once it's confirmed.
+func applyFixes(l *Linter, r *plextrac.Report, findings []*plextrac.Finding, yes bool) ([]error, error) {
+	sections, warnings, err := r.Sections()
+	if err != nil {
+		return warnings, err
+	}
+
+	for _, s := range sections {
+		content, rules := l.FixSection(r, s)
+		if len(rules) == 0 {
+			continue
+		}
+
+		ok, err := confirmFix(fmt.Sprintf("narrative %q", s.Title), s.Content, content, rules, yes)
+		if err != nil {
+			return warnings, err
+		}
+
+		if !ok {
+			continue
+		}
+
+		warnings2, err := r.SetSectionContent(s.ID, content)
+		warnings = append(warnings, warnings2...)
+
+		if err != nil {
+			return warnings, fmt.Errorf("unable to save fixes to %s: %w", s.Title, err)
 		}
 	}
 
-	for _, warning := range warnings {
-		slog.Warn("Warning while creating plextrac instance",
-			"warning", warning,
-		)
+	for _, f := range findings {
+		warnings2, err := f.EnsureFull()
+		warnings = append(warnings, warnings2...)
+
+		if err != nil {
+			return warnings, err
+		}
+
+		evidence, rules := l.FixFinding(r, f)
+		if len(rules) == 0 {
+			continue
+		}
+
+		ok, err := confirmFix(fmt.Sprintf("finding %q", f.Name), f.Evidence, evidence, rules, yes)
+		if err != nil {
+			return warnings, err
+		}
+
+		if !ok {
+			continue
+		}
+
+		warnings2, err = f.SetEvidence(evidence)
+		warnings = append(warnings, warnings2...)
+
+		if err != nil {
+			return warnings, fmt.Errorf("unable to save fixes to %s: %w", f.Name, err)
+		}
 	}
 
-	return nil
+	return warnings, nil
 }
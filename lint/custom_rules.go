@@ -0,0 +1,275 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package lint
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/brimstone/plextraccli/plextrac"
+	"github.com/brimstone/plextraccli/richtext"
+	"github.com/brimstone/plextraccli/types"
+)
+
+// CustomTypes are the types of custom rules.
+var CustomTypes = []string{"regex", "banned", "required", "case"}
+
+// CustomScopes are what custom rules can check.
+var CustomScopes = []string{"sections", "evidence", "descriptions", "recommendations"}
+
+// customCheck finds problems in the prose of some content, describing them
+// after where they are, eg: narrative "Summary".
+type customCheck func(content string, where string) []Problem
+
+// customRule builds a rule from the config.
+func customRule(cfg types.LintRule) (*Rule, error) {
+	if cfg.ID == "" {
+		return nil, errors.New("custom lint rule has no id")
+	}
+
+	r := &Rule{
+		ID:          cfg.ID,
+		Name:        cfg.Name,
+		Severity:    Warning,
+		Description: cfg.Description,
+	}
+
+	if r.Name == "" {
+		r.Name = strings.ToLower(cfg.ID)
+	}
+
+	if cfg.Severity != "" {
+		severity, err := ParseSeverity(cfg.Severity)
+		if err != nil {
+			return nil, fmt.Errorf("lint rule %s: %w", r, err)
+		}
+
+		r.Severity = severity
+	}
+
+	scopes := CustomScopes
+	if len(cfg.Scopes) > 0 {
+		scopes = nil
+
+		for _, s := range cfg.Scopes {
+			s = strings.ToLower(strings.TrimSpace(s))
+			if !slices.Contains(CustomScopes, s) {
+				return nil, fmt.Errorf("lint rule %s: unknown scope %q, must be any of: %s", r, s, strings.Join(CustomScopes, ", "))
+			}
+
+			scopes = append(scopes, s)
+		}
+	}
+
+	check, err := newCustomCheck(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("lint rule %s: %w", r, err)
+	}
+
+	if r.Description == "" {
+		r.Description = fmt.Sprintf("Custom %s rule for %s", strings.ToLower(cfg.Type), strings.Join(scopes, ", "))
+	}
+
+	var objects []string
+
+	if slices.Contains(scopes, "sections") {
+		objects = append(objects, string(ScopeSection))
+
+		r.Section = func(l *Linter, s plextrac.Section) []Problem {
+			if !titled(cfg.Titles, s.Title) {
+				return nil
+			}
+
+			return check(s.Content, fmt.Sprintf("narrative %q", s.Title))
+		}
+	}
+
+	fields := slices.DeleteFunc(slices.Clone(scopes), func(s string) bool { return s == "sections" })
+	if len(fields) > 0 {
+		objects = append(objects, string(ScopeFinding))
+
+		r.Finding = func(l *Linter, f *plextrac.Finding) []Problem {
+			var problems []Problem
+
+			for _, field := range fields {
+				content := map[string]string{
+					"evidence":        f.Evidence,
+					"descriptions":    f.Description,
+					"recommendations": f.Recommendations,
+				}[field]
+
+				problems = append(problems, check(content, fmt.Sprintf("finding %q %s", f.Name, strings.TrimSuffix(field, "s")))...)
+			}
+
+			return problems
+		}
+	}
+
+	r.Scope = Scope(strings.Join(objects, ","))
+
+	if strings.EqualFold(cfg.Type, "case") {
+		// Only sections and evidence are fixed
+		r.Fix = func(l *Linter, scope Scope, title string, content string) string {
+			if scope == ScopeFinding && !slices.Contains(fields, "evidence") {
+				return content
+			}
+
+			if scope == ScopeSection && !titled(cfg.Titles, title) {
+				return content
+			}
+
+			return fixCapitalization(content, cfg.Phrases)
+		}
+	}
+
+	return r, nil
+}
+
+// titled reports whether a section with the title is checked by a rule
+// limited to titles, or by any rule when titles is empty.
+func titled(titles []string, title string) bool {
+	if len(titles) == 0 {
+		return true
+	}
+
+	return slices.ContainsFunc(titles, func(t string) bool {
+		return strings.EqualFold(strings.TrimSpace(t), strings.TrimSpace(title))
+	})
+}
+
+// newCustomCheck makes the check for the type of the custom rule.
+func newCustomCheck(cfg types.LintRule) (customCheck, error) {
+	message := func(format string, args ...any) string {
+		if cfg.Message != "" {
+			return cfg.Message
+		}
+
+		return fmt.Sprintf(format, args...)
+	}
+
+	switch strings.ToLower(cfg.Type) {
+	case "regex":
+		if cfg.Pattern == "" {
+			return nil, errors.New("regex rule has no pattern")
+		}
+
+		re, err := regexp.Compile(cfg.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("bad pattern: %w", err)
+		}
+
+		return func(content string, where string) []Problem {
+			var problems []Problem
+			for _, m := range re.FindAllString(prose(content), -1) {
+				problems = append(problems, problem(m, "%s: %s", where, message("%q matches %s", m, cfg.Pattern)))
+			}
+
+			return problems
+		}, nil
+	case "banned":
+		res, err := phraseRegexps(cfg.Phrases)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(content string, where string) []Problem {
+			text := prose(content)
+
+			var problems []Problem
+
+			for _, re := range res {
+				for _, m := range re.FindAllString(text, -1) {
+					problems = append(problems, problem(m, "%s: %s", where, message("uses %q", m)))
+				}
+			}
+
+			return problems
+		}, nil
+	case "required":
+		res, err := phraseRegexps(cfg.Phrases)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(content string, where string) []Problem {
+			text := prose(content)
+
+			var problems []Problem
+
+			for i, re := range res {
+				if !re.MatchString(text) {
+					problems = append(problems, problem("", "%s: %s", where, message("is missing %q", cfg.Phrases[i])))
+				}
+			}
+
+			return problems
+		}, nil
+	case "case":
+		if len(cfg.Phrases) == 0 {
+			return nil, errors.New("case rule has no phrases")
+		}
+
+		return func(content string, where string) []Problem {
+			var problems []Problem
+			for _, p := range checkCapitalization(prose(content), cfg.Phrases) {
+				problems = append(problems, problem(p.Snippet, "%s: %s", where, message("%s", p.Message)))
+			}
+
+			return problems
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unknown type %q, must be one of: %s", cfg.Type, strings.Join(CustomTypes, ", "))
+}
+
+// phraseRegexps match the phrases as whole words, ignoring case.
+func phraseRegexps(phrases []string) ([]*regexp.Regexp, error) {
+	if len(phrases) == 0 {
+		return nil, errors.New("rule has no phrases")
+	}
+
+	var res []*regexp.Regexp
+
+	for _, p := range phrases {
+		words := strings.Fields(p)
+		for i, w := range words {
+			words[i] = regexp.QuoteMeta(w)
+		}
+
+		re, err := regexp.Compile(`(?i)\b` + strings.Join(words, `\s+`) + `\b`)
+		if err != nil {
+			return nil, fmt.Errorf("bad phrase %q: %w", p, err)
+		}
+
+		res = append(res, re)
+	}
+
+	return res, nil
+}
+
+// prose is the text of rich text outside of code, with the whitespace
+// collapsed, so custom rules don't match markup or command output.
+func prose(content string) string {
+	var texts []string
+
+	richtext.MapText(content, func(text string) string {
+		texts = append(texts, text)
+
+		return text
+	})
+
+	return strings.Join(strings.Fields(strings.Join(texts, " ")), " ")
+}
+
+// mergeWords adds the words to the defaults, replacing the defaults spelled
+// the same ignoring case.
+func mergeWords(defaults []string, words []string) []string {
+	merged := slices.DeleteFunc(slices.Clone(defaults), func(d string) bool {
+		return slices.ContainsFunc(words, func(w string) bool { return strings.EqualFold(d, w) })
+	})
+
+	return append(merged, words...)
+}
@@ -0,0 +1,236 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package lint_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/brimstone/plextraccli/lint"
+	"github.com/brimstone/plextraccli/plextrac"
+	"github.com/brimstone/plextraccli/types"
+)
+
+func customRule(t *testing.T, l *lint.Linter, id string) *lint.Rule {
+	t.Helper()
+
+	i := slices.IndexFunc(l.Rules(), func(r *lint.Rule) bool { return r.ID == id })
+	if i == -1 {
+		t.Fatalf("no rule %s", id)
+	}
+
+	return l.Rules()[i]
+}
+
+func TestCustomRules_sections(t *testing.T) {
+	t.Parallel()
+
+	l := newLinter(t, types.LintConfig{
+		// ACME002 does the same
+		Disable: []string{"first-person"},
+		Rules: []types.LintRule{
+			{ID: "ACME001", Name: "active-directory", Type: "banned", Phrases: []string{"AD"}, Message: "spell out Active Directory"},
+			{ID: "ACME002", Type: "regex", Pattern: `\bI\b`, Severity: "error", Scopes: []string{"sections"}},
+			{ID: "ACME003", Type: "required", Phrases: []string{"in scope"}, Scopes: []string{"sections"}, Titles: []string{"Scope"}},
+			{ID: "ACME004", Type: "case", Phrases: []string{"ACME Corp"}, Scopes: []string{"sections"}},
+		},
+	})
+
+	tests := []struct {
+		name     string
+		title    string
+		content  string
+		expected []string
+	}{
+		{
+			name:    "clean",
+			title:   "Summary",
+			content: "<p>Active Directory at ACME Corp was compromised.</p>",
+		},
+		{
+			name:     "banned",
+			title:    "Summary",
+			content:  "<p>The AD domain fell.</p>",
+			expected: []string{"ACME001"},
+		},
+		{
+			name:    "banned only in prose",
+			title:   "Summary",
+			content: "<p>The domain fell.</p><pre><code>Get-ADUser</code></pre><a href=\"ad.html\">link</a>",
+		},
+		{
+			name:     "regex",
+			title:    "Summary",
+			content:  "<p>I relayed hashes.</p>",
+			expected: []string{"ACME002"},
+		},
+		{
+			name:     "required",
+			title:    "Scope",
+			content:  "<p>These hosts were tested.</p>",
+			expected: []string{"ACME003"},
+		},
+		{
+			name:    "required across markup",
+			title:   "scope",
+			content: "<p>These hosts were <strong>in</strong>\n scope.</p>",
+		},
+		{
+			name:    "required only for titles",
+			title:   "Summary",
+			content: "<p>These hosts were tested.</p>",
+		},
+		{
+			name:     "case",
+			title:    "Summary",
+			content:  "<p>acme corp was compromised.</p>",
+			expected: []string{"ACME004"},
+		},
+		{
+			name:     "suppressed",
+			title:    "Summary",
+			content:  "<!-- lint-ignore active-directory --><p>I took over AD.</p>",
+			expected: []string{"ACME002"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := ruleIDs(l.Sections([]plextrac.Section{{ID: "1", Title: tt.title, Content: tt.content}}))
+			if !slices.Equal(got, tt.expected) {
+				t.Errorf("rules = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCustomRules_findings(t *testing.T) {
+	t.Parallel()
+
+	l := newLinter(t, types.LintConfig{
+		Rules: []types.LintRule{
+			{ID: "ACME001", Type: "banned", Phrases: []string{"AD"}, Scopes: []string{"descriptions", "evidence"}, Message: "spell out Active Directory"},
+		},
+	})
+
+	r := customRule(t, l, "ACME001")
+	if r.Section != nil || r.Scope != lint.ScopeFinding {
+		t.Errorf("expected a finding rule, got scope %q", r.Scope)
+	}
+
+	problems := r.Finding(l, &plextrac.Finding{
+		Name:            "SMB Signing",
+		Description:     "<p>AD hosts</p>",
+		Recommendations: "<p>Harden AD</p>",
+		Evidence:        "<p>ad</p>",
+	})
+
+	var messages []string
+	for _, p := range problems {
+		messages = append(messages, p.Message)
+	}
+
+	expected := []string{
+		`finding "SMB Signing" description: spell out Active Directory`,
+		`finding "SMB Signing" evidence: spell out Active Directory`,
+	}
+	if !slices.Equal(messages, expected) {
+		t.Errorf("messages = %q, want %q", messages, expected)
+	}
+}
+
+func TestCustomRules_fix(t *testing.T) {
+	t.Parallel()
+
+	l := newLinter(t, types.LintConfig{
+		ToolNames: []string{"BloodHound", "netexec"},
+		Rules: []types.LintRule{
+			{ID: "ACME004", Type: "case", Phrases: []string{"ACME Corp"}},
+		},
+	})
+
+	got, rules := l.FixSection(nil, plextrac.Section{
+		Content: "<p>acme corp ran Bloodhound and NetExec.</p><pre><code>acme corp</code></pre>",
+	})
+
+	expected := "<p>ACME Corp ran BloodHound and netexec.</p><pre><code>acme corp</code></pre>"
+	if got != expected {
+		t.Errorf("FixSection() = %q, want %q", got, expected)
+	}
+
+	var ids []string
+	for _, r := range rules {
+		ids = append(ids, r.ID)
+	}
+
+	if !slices.Equal(ids, []string{"PT103", "ACME004"}) {
+		t.Errorf("rules = %v", ids)
+	}
+}
+
+func TestCustomRules_fix_titles(t *testing.T) {
+	t.Parallel()
+
+	l := newLinter(t, types.LintConfig{
+		Rules: []types.LintRule{
+			{ID: "ACME004", Type: "case", Phrases: []string{"ACME Corp"}, Scopes: []string{"sections"}, Titles: []string{"Summary"}},
+		},
+	})
+
+	tests := []struct {
+		title    string
+		expected string
+	}{
+		{title: "Summary", expected: "<p>ACME Corp was compromised.</p>"},
+		{title: " summary ", expected: "<p>ACME Corp was compromised.</p>"},
+		{title: "Scope", expected: "<p>acme corp was compromised.</p>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+
+			s := plextrac.Section{Title: tt.title, Content: "<p>acme corp was compromised.</p>"}
+
+			got, _ := l.FixSection(nil, s)
+			if got != tt.expected {
+				t.Errorf("FixSection() = %q, want %q", got, tt.expected)
+			}
+
+			// Fixes only what's reported
+			if reported := len(l.Sections([]plextrac.Section{s})) > 0; reported != (got != s.Content) {
+				t.Errorf("reported = %v, but fixed = %v", reported, got != s.Content)
+			}
+		})
+	}
+}
+
+func TestCustomRules_bad_config(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		rule types.LintRule
+	}{
+		{"no id", types.LintRule{Type: "banned", Phrases: []string{"AD"}}},
+		{"unknown type", types.LintRule{ID: "X1", Type: "spelling"}},
+		{"bad pattern", types.LintRule{ID: "X1", Type: "regex", Pattern: "("}},
+		{"no phrases", types.LintRule{ID: "X1", Type: "required"}},
+		{"unknown scope", types.LintRule{ID: "X1", Type: "banned", Phrases: []string{"AD"}, Scopes: []string{"titles"}}},
+		{"bad severity", types.LintRule{ID: "X1", Type: "banned", Phrases: []string{"AD"}, Severity: "fatal"}},
+		{"conflict", types.LintRule{ID: "PT101", Type: "banned", Phrases: []string{"AD"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := lint.NewLinter(types.LintConfig{Rules: []types.LintRule{tt.rule}}, nil)
+			if err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,403 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package lint
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/brimstone/plextraccli/plextrac"
+	"github.com/brimstone/plextraccli/richtext"
+)
+
+var findingRules = []*Rule{
+	{
+		ID:          "PT201",
+		Name:        "no-assets",
+		Severity:    Error,
+		Scope:       ScopeFinding,
+		Description: "A finding has no affected assets",
+		Finding: func(l *Linter, f *plextrac.Finding) []Problem {
+			assets, warnings, err := f.Assets()
+			for _, w := range warnings {
+				l.Warn(w)
+			}
+
+			if err != nil {
+				l.Warn(fmt.Errorf("finding %q: %w", f.Name, err))
+
+				return nil
+			}
+
+			if len(assets) == 0 {
+				return []Problem{problem("", "finding %q has no assets", f.Name)}
+			}
+
+			return nil
+		},
+	},
+	{
+		ID:          "PT202",
+		Name:        "no-evidence",
+		Severity:    Error,
+		Scope:       ScopeFinding,
+		Description: "A finding has no evidence",
+		Finding: func(l *Linter, f *plextrac.Finding) []Problem {
+			if f.Evidence == "" {
+				return []Problem{problem("", "finding %q has no evidence", f.Name)}
+			}
+
+			return nil
+		},
+	},
+	{
+		ID:          "PT203",
+		Name:        "no-screenshot",
+		Severity:    Warning,
+		Scope:       ScopeFinding,
+		Description: "A finding has evidence, but no screenshots in it",
+		Finding: func(l *Linter, f *plextrac.Finding) []Problem {
+			if f.Evidence != "" && !strings.Contains(f.Evidence, "<figure") {
+				return []Problem{problem("", "finding %q has no screenshot for evidence", f.Name)}
+			}
+
+			return nil
+		},
+	},
+	{
+		ID:          "PT204",
+		Name:        "caption-period",
+		Severity:    Warning,
+		Scope:       ScopeFinding,
+		Description: "A finding has a caption ending with a period",
+		Finding: func(l *Linter, f *plextrac.Finding) []Problem {
+			var problems []Problem
+			for _, caption := range captionsWithPeriods(f.Evidence) {
+				problems = append(problems, problem(caption, "finding %q has a caption ending with a period", f.Name))
+			}
+
+			return problems
+		},
+		Fix: func(l *Linter, scope Scope, title string, content string) string {
+			return fixCaptionPeriods(content)
+		},
+	},
+	{
+		ID:          "PT205",
+		Name:        "tool-name-case",
+		Severity:    Warning,
+		Scope:       ScopeFinding,
+		Description: "A finding has a tool name with the wrong case, such as netexec for NetExec",
+		Finding: func(l *Linter, f *plextrac.Finding) []Problem {
+			var problems []Problem
+			for _, p := range checkCapitalization(prose(f.Evidence), l.toolNames) {
+				p.Message = fmt.Sprintf("finding %q has a misspelling: %s", f.Name, p.Message)
+				problems = append(problems, p)
+			}
+
+			return problems
+		},
+		Fix: func(l *Linter, scope Scope, title string, content string) string {
+			return fixCapitalization(content, l.toolNames)
+		},
+	},
+	{
+		ID:          "PT206",
+		Name:        "secret",
+		Severity:    Error,
+		Scope:       ScopeFinding,
+		Description: "A finding's evidence has a secret, such as an NTLM hash, that the redact command would mask",
+		Finding: func(l *Linter, f *plextrac.Finding) []Problem {
+			if l.Redactor == nil {
+				return nil
+			}
+
+			var problems []Problem
+			for _, m := range l.Redactor.Find(f.Evidence) {
+				problems = append(problems, problem(m.Preview(), "finding %q has a %s secret", f.Name, m.Detector))
+			}
+
+			return problems
+		},
+	},
+	{
+		ID:          "PT207",
+		Name:        "unpublished",
+		Severity:    Warning,
+		Scope:       ScopeFinding,
+		Description: "A finding is still a draft",
+		Finding: func(l *Linter, f *plextrac.Finding) []Problem {
+			if !f.Published {
+				return []Problem{problem("", "finding %q is not published", f.Name)}
+			}
+
+			return nil
+		},
+	},
+	{
+		ID:          "PT208",
+		Name:        "spelling",
+		Severity:    Warning,
+		Scope:       ScopeFinding,
+		Description: "A finding's title, description, recommendations or evidence has a word that isn't in the dictionary",
+		Finding: func(l *Linter, f *plextrac.Finding) []Problem {
+			where := fmt.Sprintf("finding %q", f.Name)
+
+			return slices.Concat(
+				checkSpelling(l, f.Name, where+" title"),
+				checkSpelling(l, f.Description, where+" description"),
+				checkSpelling(l, f.Recommendations, where+" recommendations"),
+				checkSpelling(l, f.Evidence, where+" evidence"),
+			)
+		},
+	},
+	{
+		ID:          "PT209",
+		Name:        "heading",
+		Severity:    Warning,
+		Scope:       ScopeFinding,
+		Description: "A finding's rich text has a level 1, 2 or 3 heading, which look out of place in the report",
+		Finding: func(l *Linter, f *plextrac.Finding) []Problem {
+			var problems []Problem
+
+			for _, field := range findingFields(f) {
+				for _, m := range bigHeading.FindAllStringSubmatch(field.content, -1) {
+					text := strings.Join(richtext.Paragraphs(m[2]), " ")
+					problems = append(problems, problem(m[0], "finding %q %s has a level %s heading: %q", f.Name, field.name, m[1], text))
+				}
+			}
+
+			return problems
+		},
+	},
+	{
+		ID:          "PT210",
+		Name:        "no-finding-tags",
+		Severity:    Warning,
+		Scope:       ScopeFinding,
+		Description: "A finding has no tags",
+		Finding: func(l *Linter, f *plextrac.Finding) []Problem {
+			if !slices.ContainsFunc(f.Tags(), func(t string) bool { return !isIgnoreTag(t) }) {
+				return []Problem{problem("", "finding %q has no tags", f.Name)}
+			}
+
+			return nil
+		},
+	},
+	{
+		ID:          "PT211",
+		Name:        "tag-not-in-report",
+		Severity:    Warning,
+		Scope:       ScopeFinding,
+		Description: "A finding has a tag that the report doesn't",
+		Finding: func(l *Linter, f *plextrac.Finding) []Problem {
+			if f.Report() == nil {
+				return nil
+			}
+
+			var problems []Problem
+
+			for _, t := range f.Tags() {
+				if !isIgnoreTag(t) && !slices.Contains(f.Report().Tags(), t) {
+					problems = append(problems, problem(t, "finding %q has tag %s, which the report doesn't", f.Name, t))
+				}
+			}
+
+			return problems
+		},
+	},
+	{
+		ID:          "PT212",
+		Name:        "no-description",
+		Severity:    Error,
+		Scope:       ScopeFinding,
+		Description: "A finding has no description",
+		Finding: func(l *Linter, f *plextrac.Finding) []Problem {
+			if isBlank(f.Description) {
+				return []Problem{problem("", "finding %q has no description", f.Name)}
+			}
+
+			return nil
+		},
+	},
+	{
+		ID:          "PT213",
+		Name:        "no-recommendations",
+		Severity:    Error,
+		Scope:       ScopeFinding,
+		Description: "A finding has no recommendations",
+		Finding: func(l *Linter, f *plextrac.Finding) []Problem {
+			if isBlank(f.Recommendations) {
+				return []Problem{problem("", "finding %q has no recommendations", f.Name)}
+			}
+
+			return nil
+		},
+	},
+	{
+		ID:          "PT214",
+		Name:        "no-references",
+		Severity:    Warning,
+		Scope:       ScopeFinding,
+		Description: "A finding has no references",
+		Finding: func(l *Linter, f *plextrac.Finding) []Problem {
+			if isBlank(f.References) {
+				return []Problem{problem("", "finding %q has no references", f.Name)}
+			}
+
+			return nil
+		},
+	},
+	{
+		ID:          "PT215",
+		Name:        "bad-reference",
+		Severity:    Error,
+		Scope:       ScopeFinding,
+		Description: "A finding's references have a URL that isn't valid",
+		Finding: func(l *Linter, f *plextrac.Finding) []Problem {
+			var problems []Problem
+
+			for _, link := range richtext.Links(f.References) {
+				if !validURL(link) {
+					problems = append(problems, problem(link, "finding %q references has an invalid URL: %q", f.Name, link))
+				}
+			}
+
+			return problems
+		},
+	},
+	{
+		ID:          "PT216",
+		Name:        "duplicate-reference",
+		Severity:    Warning,
+		Scope:       ScopeFinding,
+		Description: "A finding's references have the same URL more than once",
+		Finding: func(l *Linter, f *plextrac.Finding) []Problem {
+			var seen, duplicates []string
+
+			for _, link := range richtext.Links(f.References) {
+				key := normalizeURL(link)
+
+				if slices.Contains(seen, key) {
+					if !slices.Contains(duplicates, key) {
+						duplicates = append(duplicates, key)
+					}
+
+					continue
+				}
+
+				seen = append(seen, key)
+			}
+
+			var problems []Problem
+			for _, d := range duplicates {
+				problems = append(problems, problem(d, "finding %q references has %q more than once", f.Name, d))
+			}
+
+			return problems
+		},
+	},
+	{
+		ID:          "PT217",
+		Name:        "cvss-severity",
+		Severity:    Warning,
+		Scope:       ScopeFinding,
+		Description: "A finding's severity doesn't match the rating of its CVSS v3 base score",
+		Finding: func(l *Linter, f *plextrac.Finding) []Problem {
+			return checkCVSSSeverity(fmt.Sprintf("finding %q", f.Name), f.Severity, f.CVSS3())
+		},
+	},
+	{
+		ID:          "PT218",
+		Name:        "bad-cvss",
+		Severity:    Error,
+		Scope:       ScopeFinding,
+		Description: "A finding's CVSS v3 vector isn't valid",
+		Finding: func(l *Linter, f *plextrac.Finding) []Problem {
+			return checkCVSSVector(fmt.Sprintf("finding %q", f.Name), f.CVSS3())
+		},
+	},
+	{
+		ID:          "PT219",
+		Name:        "writeup-severity",
+		Severity:    Warning,
+		Scope:       ScopeFinding,
+		Description: "A finding's severity isn't the severity of the writeup it was created from",
+		Finding: func(l *Linter, f *plextrac.Finding) []Problem {
+			id := f.WriteupID()
+			if id == "" {
+				return nil
+			}
+
+			i := slices.IndexFunc(l.Library, func(w *plextrac.Writeup) bool { return w.ID == id })
+			if i == -1 {
+				return nil
+			}
+
+			w := l.Library[i]
+			if w.Severity == "" || strings.EqualFold(w.Severity, f.Severity) {
+				return nil
+			}
+
+			return []Problem{problem(f.Severity, "finding %q is %s, but writeup %q it was created from is %s", f.Name, f.Severity, w.Title, w.Severity)}
+		},
+	},
+}
+
+// bigHeading is a level 1, 2 or 3 heading.
+var bigHeading = regexp.MustCompile(`(?is)<h([1-3])\b[^>]*>(.*?)</h[1-3]\s*>`)
+
+type findingField struct {
+	name    string
+	content string
+}
+
+// findingFields are the rich text fields of a finding, by name.
+func findingFields(f *plextrac.Finding) []findingField {
+	return []findingField{
+		{"description", f.Description},
+		{"recommendations", f.Recommendations},
+		{"references", f.References},
+		{"evidence", f.Evidence},
+	}
+}
+
+// isBlank reports whether rich text has no text or images.
+func isBlank(content string) bool {
+	return len(richtext.Paragraphs(content)) == 0 && !strings.Contains(content, "<img")
+}
+
+// validURL reports whether a reference is a web URL that could be followed.
+func validURL(link string) bool {
+	u, err := url.Parse(link)
+	if err != nil {
+		return false
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return false
+	}
+
+	host := u.Hostname()
+
+	return host != "" && (strings.Contains(host, ".") || host == "localhost") && !strings.ContainsAny(host, "_ ")
+}
+
+// normalizeURL makes URLs that go to the same place the same, eg: without a
+// trailing slash or fragment.
+func normalizeURL(link string) string {
+	u, err := url.Parse(link)
+	if err != nil {
+		return link
+	}
+
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(u.Host)
+	u.Fragment = ""
+	u.Path = strings.TrimSuffix(u.Path, "/")
+
+	return u.String()
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package lint_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/brimstone/plextraccli/importer"
+	"github.com/brimstone/plextraccli/plextrac"
+	"github.com/brimstone/plextraccli/types"
+)
+
+func TestFindingRules_structure(t *testing.T) {
+	t.Parallel()
+
+	l := newLinter(t, types.LintConfig{})
+
+	tests := []struct {
+		name     string
+		id       string
+		finding  plextrac.Finding
+		expected []string
+	}{
+		{
+			name: "heading",
+			id:   "PT209",
+			finding: plextrac.Finding{
+				Description: "<h2>Impact</h2><p>Bad.</p><h4>Fine</h4>",
+				Evidence:    "<H1 class=\"x\">Proof</H1>",
+			},
+			expected: []string{
+				`finding "SMB Signing" description has a level 2 heading: "Impact"`,
+				`finding "SMB Signing" evidence has a level 1 heading: "Proof"`,
+			},
+		},
+		{
+			name:     "tool name case",
+			id:       "PT205",
+			finding:  plextrac.Finding{Evidence: "<p>Ran netexec.</p><pre><code>netexec smb</code></pre><p><img src=\"/uploads/netexec.png\"></p>"},
+			expected: []string{`finding "SMB Signing" has a misspelling: "NetExec" has the wrong case`},
+		},
+		{
+			name:     "no description",
+			id:       "PT212",
+			finding:  plextrac.Finding{Description: "<p> </p>"},
+			expected: []string{`finding "SMB Signing" has no description`},
+		},
+		{
+			name:    "image description",
+			id:      "PT212",
+			finding: plextrac.Finding{Description: `<p><img src="a.png"></p>`},
+		},
+		{
+			name:     "no recommendations",
+			id:       "PT213",
+			finding:  plextrac.Finding{Recommendations: "<pre><code>gpupdate</code></pre>"},
+			expected: []string{`finding "SMB Signing" has no recommendations`},
+		},
+		{
+			name:     "no references",
+			id:       "PT214",
+			expected: []string{`finding "SMB Signing" has no references`},
+		},
+		{
+			name: "bad reference",
+			id:   "PT215",
+			finding: plextrac.Finding{
+				References: `<p><a href="https://learn.microsoft.com/">Microsoft</a> http//example.com ftp://example.com/x https://exa_mple.com</p>`,
+			},
+			expected: []string{
+				`finding "SMB Signing" references has an invalid URL: "http//example.com"`,
+				`finding "SMB Signing" references has an invalid URL: "ftp://example.com/x"`,
+				`finding "SMB Signing" references has an invalid URL: "https://exa_mple.com"`,
+			},
+		},
+		{
+			name: "duplicate reference",
+			id:   "PT216",
+			finding: plextrac.Finding{
+				References: `<ul><li><a href="https://Example.com/smb/">SMB</a></li><li>https://example.com/smb</li>` +
+					`<li>https://example.com/smb#signing</li><li>https://example.com/ldap</li></ul>`,
+			},
+			expected: []string{`finding "SMB Signing" references has "https://example.com/smb" more than once`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := tt.finding
+			f.Name = "SMB Signing"
+
+			var messages []string
+			for _, p := range ruleByID(t, tt.id).Finding(l, &f) {
+				messages = append(messages, p.Message)
+			}
+
+			if !slices.Equal(messages, tt.expected) {
+				t.Errorf("messages = %q, want %q", messages, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFindingRules_cvss(t *testing.T) {
+	t.Parallel()
+
+	l := newLinter(t, types.LintConfig{})
+
+	tests := []struct {
+		name     string
+		severity string
+		vector   string
+		expected []string
+	}{
+		{
+			name:     "matches",
+			severity: "Critical",
+			vector:   "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H",
+		},
+		{
+			name:     "no vector",
+			severity: "High",
+		},
+		{
+			name:     "mismatch",
+			severity: "High",
+			vector:   "CVSS:3.0/AV:N/AC:L/PR:N/UI:N/S:U/C:N/I:L/A:N",
+			expected: []string{`finding "SMB Signing" is High, but its CVSS 3.0 base score of 5.3 is Medium`},
+		},
+		{
+			name:     "malformed",
+			severity: "Medium",
+			vector:   "CVSS:3.0/AV:N/AC:L/PR:N/UI:N/S:U/C:N/I:L",
+			expected: []string{`finding "SMB Signing" has an invalid CVSS vector: vector is missing metric A`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Findings are linted as the importer makes them
+			imported := importer.Finding{Title: "SMB Signing", Severity: tt.severity, CVSS3Vector: tt.vector, CVSS3Score: 5.3}
+
+			f, _, err := plextrac.NewFinding(imported.Doc())
+			if err != nil {
+				t.Fatalf("NewFinding() returned error: %v", err)
+			}
+
+			var messages []string
+
+			for _, id := range []string{"PT217", "PT218"} {
+				for _, p := range ruleByID(t, id).Finding(l, f) {
+					messages = append(messages, p.Message)
+				}
+			}
+
+			if !slices.Equal(messages, tt.expected) {
+				t.Errorf("messages = %q, want %q", messages, tt.expected)
+			}
+		})
+	}
+}
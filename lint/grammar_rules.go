@@ -0,0 +1,219 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package lint
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/brimstone/plextraccli/grammar"
+	"github.com/brimstone/plextraccli/plextrac"
+	"github.com/brimstone/plextraccli/richtext"
+	"github.com/brimstone/plextraccli/types"
+)
+
+const (
+	defaultMaxPassive = 0.25
+	defaultMaxWords   = 40
+	// minPassiveSentences is how many sentences there have to be before
+	// the passive voice is counted
+	minPassiveSentences = 4
+)
+
+// Tenses are the tenses grammar profiles can require.
+var Tenses = []string{"", "any", "past"}
+
+// defaultGrammar are the grammar profiles after the configured ones. Attack
+// narratives are in the past tense.
+var defaultGrammar = []types.GrammarProfile{
+	{Sections: []string{"*narrative*", "*attack path*"}, Tense: "past"},
+	{Sections: []string{"*"}, Findings: true},
+}
+
+// grammarCheck finds problems in sentences, describing them after where they
+// are, eg: narrative "Summary".
+type grammarCheck func(p types.GrammarProfile, where string, sentences []string) []Problem
+
+var grammarRules = []*Rule{
+	grammarRule("PT401", "gendered-pronoun", Warning, "A narrative or finding uses a gendered pronoun, such as he, instead of they",
+		func(p types.GrammarProfile, where string, sentences []string) []Problem {
+			var problems []Problem
+
+			for _, s := range sentences {
+				for _, pronoun := range grammar.GenderedPronouns(s) {
+					problems = append(problems, problem(s, "%s uses %q, use %q instead", where, pronoun.Word, pronoun.Neutral))
+				}
+			}
+
+			return problems
+		}),
+	grammarRule("PT402", "present-tense", Warning, "An attack narrative is narrated in the present tense, such as obtains for obtained",
+		func(p types.GrammarProfile, where string, sentences []string) []Problem {
+			if !strings.EqualFold(p.Tense, "past") {
+				return nil
+			}
+
+			var problems []Problem
+
+			for _, s := range sentences {
+				if phrase := grammar.PresentTense(s); phrase != "" {
+					problems = append(problems, problem(s, "%s narrates in the present tense: %q", where, phrase))
+				}
+			}
+
+			return problems
+		}),
+	grammarRule("PT403", "passive-voice", Info, "Too many of the sentences of a narrative or finding are in the passive voice",
+		func(p types.GrammarProfile, where string, sentences []string) []Problem {
+			if len(sentences) < minPassiveSentences {
+				return nil
+			}
+
+			var passive []string
+
+			for _, s := range sentences {
+				if grammar.Passive(s) != "" {
+					passive = append(passive, s)
+				}
+			}
+
+			if float64(len(passive)) <= p.MaxPassive*float64(len(sentences)) {
+				return nil
+			}
+
+			return []Problem{problem(passive[0], "%s has %d of %d sentences in the passive voice, more than %.0f%%, eg: %q",
+				where, len(passive), len(sentences), p.MaxPassive*100, grammar.Passive(passive[0]))}
+		}),
+	grammarRule("PT404", "long-sentence", Info, "A narrative or finding has a sentence with too many words",
+		func(p types.GrammarProfile, where string, sentences []string) []Problem {
+			var problems []Problem
+
+			for _, s := range sentences {
+				if words := len(grammar.Words(s)); words > p.MaxWords {
+					problems = append(problems, problem(s, "%s has a sentence of %d words, more than %d", where, words, p.MaxWords))
+				}
+			}
+
+			return problems
+		}),
+	grammarRule("PT405", "first-person", Warning, "A narrative or finding is written in the first person, such as we or I",
+		func(p types.GrammarProfile, where string, sentences []string) []Problem {
+			var problems []Problem
+
+			for _, s := range sentences {
+				pronouns := grammar.FirstPerson(s)
+				if len(pronouns) == 0 {
+					continue
+				}
+
+				var quoted []string
+				for _, pronoun := range pronouns {
+					quoted = append(quoted, fmt.Sprintf("%q", pronoun))
+				}
+
+				problems = append(problems, problem(s, "%s is in the first person: %s", where, strings.Join(quoted, ", ")))
+			}
+
+			return problems
+		}),
+}
+
+// grammarRule makes a rule of a grammar check, checking narratives and the
+// description and recommendations of findings by their grammar profiles.
+func grammarRule(id string, name string, severity Severity, description string, check grammarCheck) *Rule {
+	r := &Rule{
+		ID:          id,
+		Name:        name,
+		Severity:    severity,
+		Scope:       ScopeSection + "," + ScopeFinding,
+		Description: description,
+	}
+
+	r.Section = func(l *Linter, s plextrac.Section) []Problem {
+		p := l.grammarProfile(s.Title, false)
+		if slices.ContainsFunc(p.Disable, r.Is) {
+			return nil
+		}
+
+		return check(p, fmt.Sprintf("narrative %q", s.Title), sentences(s.Content))
+	}
+
+	r.Finding = func(l *Linter, f *plextrac.Finding) []Problem {
+		p := l.grammarProfile("", true)
+		if slices.ContainsFunc(p.Disable, r.Is) {
+			return nil
+		}
+
+		return slices.Concat(
+			check(p, fmt.Sprintf("finding %q description", f.Name), sentences(f.Description)),
+			check(p, fmt.Sprintf("finding %q recommendations", f.Name), sentences(f.Recommendations)),
+		)
+	}
+
+	return r
+}
+
+// sentences are the sentences of the paragraphs of the rich text.
+func sentences(content string) []string {
+	var s []string
+	for _, p := range richtext.Paragraphs(content) {
+		s = append(s, grammar.Sentences(p)...)
+	}
+
+	return s
+}
+
+// grammarProfile is the first grammar profile for the narrative with the
+// title, or for findings, with the defaults filled in.
+func (l *Linter) grammarProfile(title string, finding bool) types.GrammarProfile {
+	var profile types.GrammarProfile
+
+	for _, p := range slices.Concat(l.Config.Grammar, defaultGrammar) {
+		if finding && p.Findings || !finding && slices.ContainsFunc(p.Sections, func(glob string) bool {
+			ok, _ := path.Match(strings.ToLower(glob), strings.ToLower(title))
+
+			return ok
+		}) {
+			profile = p
+
+			break
+		}
+	}
+
+	if profile.MaxPassive == 0 {
+		profile.MaxPassive = defaultMaxPassive
+	}
+
+	if profile.MaxWords == 0 {
+		profile.MaxWords = defaultMaxWords
+	}
+
+	return profile
+}
+
+// checkGrammarConfig checks the grammar profiles refer to rules that exist,
+// with tenses and globs that make sense.
+func (l *Linter) checkGrammarConfig() error {
+	for _, p := range l.Config.Grammar {
+		for _, ref := range p.Disable {
+			if !slices.ContainsFunc(grammarRules, func(r *Rule) bool { return r.Is(ref) }) {
+				return fmt.Errorf("unknown grammar rule %q", ref)
+			}
+		}
+
+		if !slices.Contains(Tenses, strings.ToLower(p.Tense)) {
+			return fmt.Errorf("unknown tense %q, must be one of: %s", p.Tense, strings.Join(Tenses[1:], ", "))
+		}
+
+		for _, glob := range p.Sections {
+			_, err := path.Match(glob, "")
+			if err != nil {
+				return fmt.Errorf("bad grammar sections %q: %w", glob, err)
+			}
+		}
+	}
+
+	return nil
+}
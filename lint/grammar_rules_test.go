@@ -0,0 +1,154 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package lint_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/brimstone/plextraccli/lint"
+	"github.com/brimstone/plextraccli/plextrac"
+	"github.com/brimstone/plextraccli/types"
+)
+
+func TestGrammarRules_sections(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		cfg      types.LintConfig
+		title    string
+		content  string
+		expected []string
+	}{
+		{
+			name:    "clean",
+			title:   "Attack Narrative",
+			content: "<p>The tester relayed hashes to the file server. They obtained a shell.</p>",
+		},
+		{
+			name:     "gendered pronoun",
+			title:    "Summary",
+			content:  "<p>The administrator reused his password.</p>",
+			expected: []string{"PT401"},
+		},
+		{
+			name:     "present tense in attack narrative",
+			title:    "Attack Narrative",
+			content:  "<p>The tester obtains a hash and relays it.</p>",
+			expected: []string{"PT402"},
+		},
+		{
+			name:    "present tense elsewhere",
+			title:   "Summary",
+			content: "<p>The tester obtains a hash and relays it.</p>",
+		},
+		{
+			name:  "passive voice",
+			title: "Summary",
+			content: "<p>The hosts were scanned. Hashes were captured. They were cracked. " +
+				"The domain was compromised. The report follows.</p>",
+			expected: []string{"PT403"},
+		},
+		{
+			name:  "passive voice configured",
+			cfg:   types.LintConfig{Grammar: []types.GrammarProfile{{Sections: []string{"summary"}, MaxPassive: 0.9}}},
+			title: "Summary",
+			content: "<p>The hosts were scanned. Hashes were captured. They were cracked. " +
+				"The domain was compromised. The report follows.</p>",
+		},
+		{
+			name:     "long sentence",
+			cfg:      types.LintConfig{Grammar: []types.GrammarProfile{{Sections: []string{"*"}, MaxWords: 5}}},
+			title:    "Summary",
+			content:  "<p>The tester relayed hashes to the file server.</p><pre><code>a b c d e f g h</code></pre>",
+			expected: []string{"PT404"},
+		},
+		{
+			name:     "first person",
+			title:    "Summary",
+			content:  "<p>We relayed hashes.</p>",
+			expected: []string{"PT405"},
+		},
+		{
+			name:    "disabled by profile",
+			cfg:     types.LintConfig{Grammar: []types.GrammarProfile{{Sections: []string{"exec*"}, Disable: []string{"PT405", "gendered-pronoun"}}}},
+			title:   "Executive Summary",
+			content: "<p>We found his password.</p>",
+		},
+		{
+			name:     "first profile wins",
+			cfg:      types.LintConfig{Grammar: []types.GrammarProfile{{Sections: []string{"*"}}}},
+			title:    "Attack Narrative",
+			content:  "<p>We obtain a hash.</p>",
+			expected: []string{"PT405"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			l := newLinter(t, tt.cfg)
+
+			got := ruleIDs(l.Sections([]plextrac.Section{{ID: "1", Title: tt.title, Content: tt.content}}))
+			if !slices.Equal(got, tt.expected) {
+				t.Errorf("rules = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGrammarRules_findings(t *testing.T) {
+	t.Parallel()
+
+	l := newLinter(t, types.LintConfig{
+		Grammar: []types.GrammarProfile{{Findings: true, Disable: []string{"first-person"}}},
+	})
+
+	f := &plextrac.Finding{
+		Name:            "SMB Signing",
+		Description:     "<p>The attacker uses his access. We relayed hashes.</p>",
+		Recommendations: "<p>Require signing so she cannot relay.</p>",
+	}
+
+	var messages []string
+
+	for _, id := range []string{"PT401", "PT402", "PT405"} {
+		for _, p := range ruleByID(t, id).Finding(l, f) {
+			messages = append(messages, p.Message)
+		}
+	}
+
+	expected := []string{
+		`finding "SMB Signing" description uses "his", use "their" instead`,
+		`finding "SMB Signing" recommendations uses "she", use "they" instead`,
+	}
+	if !slices.Equal(messages, expected) {
+		t.Errorf("messages = %q, want %q", messages, expected)
+	}
+}
+
+func TestGrammarRules_bad_config(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		profile types.GrammarProfile
+	}{
+		{"unknown rule", types.GrammarProfile{Disable: []string{"contraction"}}},
+		{"unknown tense", types.GrammarProfile{Tense: "future"}},
+		{"bad glob", types.GrammarProfile{Sections: []string{"[narrative"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := lint.NewLinter(types.LintConfig{Grammar: []types.GrammarProfile{tt.profile}}, nil)
+			if err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,419 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package lint
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/brimstone/plextraccli/plextrac"
+	"github.com/brimstone/plextraccli/redact"
+	"github.com/brimstone/plextraccli/spell"
+	"github.com/brimstone/plextraccli/types"
+)
+
+// IgnoreTag is the tag that suppresses every rule for a report or finding.
+// Adding a rule's ID or name, eg: lint-ignore-pt203 or
+// lint-ignore-no-screenshot, suppresses just that rule.
+const IgnoreTag = "lint-ignore"
+
+// ignoreComment is the HTML comment that suppresses rules for a section or
+// finding, eg: <!-- lint-ignore PT101, contraction --> or, for every rule,
+// <!-- lint-ignore -->
+var ignoreComment = regexp.MustCompile(`(?i)<!--\s*lint-ignore\b([^>]*?)\s*-->`)
+
+// Issue is a problem found by a rule, and where.
+type Issue struct {
+	Rule     *Rule
+	Severity Severity
+	Scope    Scope
+	// ObjectID and Object are the ID and name of what has the issue
+	ObjectID string
+	Object   string
+	// Section is the title of the narrative section, for section issues
+	Section string
+	Message string
+	Snippet string
+}
+
+// Linter runs the enabled rules at their configured severities.
+type Linter struct {
+	Config   types.LintConfig
+	Redactor *redact.Redactor
+	// Dictionary is the English words, and the team's, for spell checking
+	Dictionary *spell.Dictionary
+	// Library is the writeups in the content library, to check findings
+	// against the writeups they were created from
+	Library []*plextrac.Writeup
+
+	rules      []*Rule
+	custom     []*Rule
+	toolNames  []string
+	severities map[*Rule]Severity
+	warnings   []error
+}
+
+// NewLinter sets up the rules by the config: the custom rules, the team
+// dictionary, which are enabled or disabled, and at what severity.
+func NewLinter(cfg types.LintConfig, redactor *redact.Redactor) (*Linter, error) {
+	l := &Linter{
+		Config:     cfg,
+		Redactor:   redactor,
+		Dictionary: spell.New(cfg.Words...),
+		toolNames:  mergeWords(toolNames, cfg.ToolNames),
+		severities: make(map[*Rule]Severity),
+	}
+
+	if cfg.Dictionary != "" {
+		words, err := spell.ReadFile(cfg.Dictionary)
+		if err != nil {
+			return nil, err
+		}
+
+		l.Dictionary.Add(words...)
+	}
+
+	for _, c := range cfg.Rules {
+		r, err := customRule(c)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, other := range l.Rules() {
+			if other.Is(r.ID) || other.Is(r.Name) {
+				return nil, fmt.Errorf("lint rule %s conflicts with %s", r, other)
+			}
+		}
+
+		l.custom = append(l.custom, r)
+	}
+
+	err := l.checkGrammarConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ref := range slices.Concat(cfg.Enable, cfg.Disable, slices.Collect(maps.Keys(cfg.Severity))) {
+		if !slices.ContainsFunc(l.Rules(), func(r *Rule) bool { return r.Is(ref) }) {
+			return nil, fmt.Errorf("unknown lint rule %q", ref)
+		}
+	}
+
+	for _, r := range l.Rules() {
+		enabled := !r.Off
+
+		if slices.ContainsFunc(cfg.Disable, r.Is) {
+			enabled = false
+		}
+
+		if slices.ContainsFunc(cfg.Enable, r.Is) {
+			enabled = true
+		}
+
+		severity := r.Severity
+
+		for ref, name := range cfg.Severity {
+			if !r.Is(ref) {
+				continue
+			}
+
+			if strings.EqualFold(name, "off") {
+				enabled = false
+
+				continue
+			}
+
+			var err error
+
+			severity, err = ParseSeverity(name)
+			if err != nil {
+				return nil, fmt.Errorf("lint rule %s: %w", r, err)
+			}
+		}
+
+		if enabled {
+			l.rules = append(l.rules, r)
+			l.severities[r] = severity
+		}
+	}
+
+	return l, nil
+}
+
+// Rules returns every rule the linter knows, the built in ones and then the
+// custom ones from the config.
+func (l *Linter) Rules() []*Rule {
+	return slices.Concat(registry, l.custom)
+}
+
+// Enabled reports whether the rule will run, and at what severity.
+func (l *Linter) Enabled(r *Rule) (Severity, bool) {
+	severity, ok := l.severities[r]
+
+	return severity, ok
+}
+
+// Warn records something that went wrong while linting, but didn't stop it.
+func (l *Linter) Warn(err error) {
+	l.warnings = append(l.warnings, err)
+}
+
+// Warnings returns what went wrong while linting.
+func (l *Linter) Warnings() []error {
+	return l.warnings
+}
+
+// ignored is what's suppressed for an object, by its tags and the comments
+// in its content.
+type ignored struct {
+	all   bool
+	rules []string
+}
+
+func (i *ignored) addTags(tags []string) {
+	for _, t := range tags {
+		t = strings.ToLower(strings.TrimSpace(t))
+
+		if t == IgnoreTag {
+			i.all = true
+		} else if ref, ok := strings.CutPrefix(t, IgnoreTag+"-"); ok {
+			i.rules = append(i.rules, ref)
+		}
+	}
+}
+
+func (i *ignored) addComments(content string) {
+	for _, m := range ignoreComment.FindAllStringSubmatch(content, -1) {
+		refs := strings.FieldsFunc(m[1], func(r rune) bool {
+			return r == ',' || r == ' ' || r == '\t' || r == '\n'
+		})
+		if len(refs) == 0 {
+			i.all = true
+		}
+
+		i.rules = append(i.rules, refs...)
+	}
+}
+
+func (i ignored) ignores(r *Rule) bool {
+	return i.all || slices.ContainsFunc(i.rules, r.Is)
+}
+
+// isIgnoreTag reports whether the tag is for suppressing rules, rather than
+// describing the report.
+func isIgnoreTag(tag string) bool {
+	tag = strings.ToLower(tag)
+
+	return tag == IgnoreTag || strings.HasPrefix(tag, IgnoreTag+"-")
+}
+
+func (l *Linter) issues(r *Rule, problems []Problem, issue Issue) []Issue {
+	var issues []Issue
+
+	for _, p := range problems {
+		i := issue
+		i.Rule = r
+		i.Severity = l.severities[r]
+		i.Message = p.Message
+		i.Snippet = p.Snippet
+		issues = append(issues, i)
+	}
+
+	return issues
+}
+
+// Report runs the report rules, and the section rules on its narratives.
+// Tags on the report suppress rules for all of it.
+func (l *Linter) Report(report *plextrac.Report) []Issue {
+	var reportIgnored ignored
+	reportIgnored.addTags(report.Tags())
+
+	var issues []Issue
+
+	for _, r := range l.rules {
+		if r.Report == nil || reportIgnored.ignores(r) {
+			continue
+		}
+
+		issues = append(issues, l.issues(r, r.Report(l, report), Issue{
+			Scope:    ScopeReport,
+			ObjectID: strconv.FormatInt(report.ID, 10),
+			Object:   report.Name,
+		})...)
+	}
+
+	sections, warnings, err := report.Sections()
+	for _, w := range warnings {
+		l.Warn(fmt.Errorf("warning parsing sections: %w", w))
+	}
+
+	if err != nil {
+		l.Warn(fmt.Errorf("error parsing sections: %w", err))
+	}
+
+	for _, s := range sections {
+		issues = append(issues, l.sections(s, reportIgnored)...)
+	}
+
+	return issues
+}
+
+// Sections runs the section rules on narrative sections.
+func (l *Linter) Sections(sections []plextrac.Section) []Issue {
+	var issues []Issue
+
+	for _, s := range sections {
+		issues = append(issues, l.sections(s, ignored{})...)
+	}
+
+	return issues
+}
+
+// sectionIgnored adds what the section suppresses to what the report does.
+func sectionIgnored(s plextrac.Section, ign ignored) ignored {
+	ign.rules = slices.Clone(ign.rules)
+	ign.addComments(s.Content)
+
+	return ign
+}
+
+// findingIgnored is what the report and finding suppress.
+func findingIgnored(report *plextrac.Report, f *plextrac.Finding) ignored {
+	var ign ignored
+	ign.addTags(report.Tags())
+	ign.addTags(f.Tags())
+
+	for _, content := range []string{f.Description, f.Recommendations, f.References, f.Evidence} {
+		ign.addComments(content)
+	}
+
+	return ign
+}
+
+func (l *Linter) sections(s plextrac.Section, ign ignored) []Issue {
+	ign = sectionIgnored(s, ign)
+
+	var issues []Issue
+
+	for _, r := range l.rules {
+		if r.Section == nil || ign.ignores(r) {
+			continue
+		}
+
+		issues = append(issues, l.issues(r, r.Section(l, s), Issue{
+			Scope:    ScopeSection,
+			ObjectID: s.ID,
+			Object:   s.Title,
+			Section:  s.Title,
+		})...)
+	}
+
+	return issues
+}
+
+// Findings runs the finding rules. Tags on the report, or the finding, and
+// comments in the finding's rich text suppress rules.
+func (l *Linter) Findings(report *plextrac.Report, findings []*plextrac.Finding) []Issue {
+	var issues []Issue
+
+	for _, f := range findings {
+		warnings, err := f.EnsureFull()
+		for _, w := range warnings {
+			l.Warn(w)
+		}
+
+		if err != nil {
+			l.Warn(fmt.Errorf("finding %q: %w", f.Name, err))
+
+			continue
+		}
+
+		ign := findingIgnored(report, f)
+
+		for _, r := range l.rules {
+			if r.Finding == nil || ign.ignores(r) {
+				continue
+			}
+
+			issues = append(issues, l.issues(r, r.Finding(l, f), Issue{
+				Scope:    ScopeFinding,
+				ObjectID: strconv.Itoa(f.ID),
+				Object:   f.Name,
+			})...)
+		}
+	}
+
+	return issues
+}
+
+// Writeups runs the writeup rules. Tags on the writeup, and comments in its
+// rich text, suppress rules.
+func (l *Linter) Writeups(writeups []*plextrac.Writeup) []Issue {
+	var issues []Issue
+
+	for _, w := range writeups {
+		var ign ignored
+		ign.addTags(w.Tags)
+
+		for _, content := range []string{w.Description, w.Recommendations, w.References} {
+			ign.addComments(content)
+		}
+
+		for _, r := range l.rules {
+			if r.Writeup == nil || ign.ignores(r) {
+				continue
+			}
+
+			issues = append(issues, l.issues(r, r.Writeup(l, w), Issue{
+				Scope:    ScopeWriteup,
+				ObjectID: w.ID,
+				Object:   w.Title,
+			})...)
+		}
+	}
+
+	return issues
+}
+
+func (l *Linter) fix(scope Scope, title string, content string, ign ignored) (string, []*Rule) {
+	var fixed []*Rule
+
+	for _, r := range l.rules {
+		if r.Fix == nil || ign.ignores(r) {
+			continue
+		}
+
+		if scope == ScopeSection && r.Section == nil || scope == ScopeFinding && r.Finding == nil {
+			continue
+		}
+
+		if after := r.Fix(l, scope, title, content); after != content {
+			content = after
+			fixed = append(fixed, r)
+		}
+	}
+
+	return content, fixed
+}
+
+// FixSection applies the fixes of the enabled rules to the content of a
+// narrative section of the report, returning the fixed content and the rules
+// that changed it.
+func (l *Linter) FixSection(report *plextrac.Report, s plextrac.Section) (string, []*Rule) {
+	var ign ignored
+	if report != nil {
+		ign.addTags(report.Tags())
+	}
+
+	return l.fix(ScopeSection, s.Title, s.Content, sectionIgnored(s, ign))
+}
+
+// FixFinding applies the fixes of the enabled rules to the evidence of a
+// finding, returning the fixed evidence and the rules that changed it.
+func (l *Linter) FixFinding(report *plextrac.Report, f *plextrac.Finding) (string, []*Rule) {
+	return l.fix(ScopeFinding, f.Name, f.Evidence, findingIgnored(report, f))
+}
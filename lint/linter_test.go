@@ -0,0 +1,355 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package lint_test
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/brimstone/plextraccli/lint"
+	"github.com/brimstone/plextraccli/plextrac"
+	"github.com/brimstone/plextraccli/redact"
+	"github.com/brimstone/plextraccli/types"
+)
+
+func newLinter(t *testing.T, cfg types.LintConfig) *lint.Linter {
+	t.Helper()
+
+	redactor, err := redact.New(types.RedactConfig{})
+	if err != nil {
+		t.Fatalf("redact.New() returned error: %v", err)
+	}
+
+	l, err := lint.NewLinter(cfg, redactor)
+	if err != nil {
+		t.Fatalf("NewLinter() returned error: %v", err)
+	}
+
+	return l
+}
+
+func ruleByID(t *testing.T, id string) *lint.Rule {
+	t.Helper()
+
+	i := slices.IndexFunc(lint.Rules(), func(r *lint.Rule) bool { return r.ID == id })
+	if i == -1 {
+		t.Fatalf("no rule %s", id)
+	}
+
+	return lint.Rules()[i]
+}
+
+// ruleIDs are the IDs of the rules with issues, in order.
+func ruleIDs(issues []lint.Issue) []string {
+	var ids []string
+	for _, i := range issues {
+		ids = append(ids, i.Rule.ID)
+	}
+
+	return ids
+}
+
+func TestRules_unique(t *testing.T) {
+	t.Parallel()
+
+	seen := make(map[string]bool)
+
+	for _, r := range lint.Rules() {
+		for _, key := range []string{r.ID, r.Name} {
+			if seen[key] {
+				t.Errorf("%s is used by more than one rule", key)
+			}
+
+			seen[key] = true
+		}
+
+		if r.Description == "" {
+			t.Errorf("%s has no description", r)
+		}
+	}
+}
+
+func TestParseSeverity(t *testing.T) {
+	t.Parallel()
+
+	for name, expected := range map[string]lint.Severity{
+		"error":   lint.Error,
+		"Warning": lint.Warning,
+		" info ":  lint.Info,
+	} {
+		got, err := lint.ParseSeverity(name)
+		if err != nil || got != expected {
+			t.Errorf("ParseSeverity(%q) = %v, %v, want %v", name, got, err, expected)
+		}
+	}
+
+	_, err := lint.ParseSeverity("fatal")
+	if err == nil {
+		t.Error("expected an error for an unknown severity")
+	}
+}
+
+func TestNewLinter_config(t *testing.T) {
+	t.Parallel()
+
+	l := newLinter(t, types.LintConfig{
+		Disable: []string{"contraction"},
+		// viper lowercases map keys
+		Severity: map[string]string{
+			"pt004":       "error",
+			"unpublished": "off",
+		},
+	})
+
+	tests := []struct {
+		id       string
+		enabled  bool
+		severity lint.Severity
+	}{
+		{id: "PT001", enabled: true, severity: lint.Error},
+		{id: "PT101", enabled: false},
+		{id: "PT004", enabled: true, severity: lint.Error},
+		{id: "PT207", enabled: false},
+	}
+
+	for _, tt := range tests {
+		severity, enabled := l.Enabled(ruleByID(t, tt.id))
+		if enabled != tt.enabled || (enabled && severity != tt.severity) {
+			t.Errorf("%s: Enabled() = %v, %v, want %v, %v", tt.id, severity, enabled, tt.severity, tt.enabled)
+		}
+	}
+}
+
+func TestNewLinter_bad_config(t *testing.T) {
+	t.Parallel()
+
+	for name, cfg := range map[string]types.LintConfig{
+		"unknown rule":     {Disable: []string{"nope"}},
+		"unknown severity": {Severity: map[string]string{"PT001": "fatal"}},
+	} {
+		_, err := lint.NewLinter(cfg, nil)
+		if err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestLinter_Sections(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		content  string
+		expected []string
+	}{
+		{
+			name:    "clean",
+			content: "<p>NetExec was used to relay hashes.</p><figure><img src=\"a.png\"><figcaption>Relayed hashes</figcaption></figure>",
+		},
+		{
+			name:     "contraction",
+			content:  "<p>The hosts didn't require SMB signing.</p>",
+			expected: []string{"PT101"},
+		},
+		{
+			name:     "curly contraction",
+			content:  "<p>The hosts didn’t require SMB signing.</p>",
+			expected: []string{"PT101"},
+		},
+		{
+			name:    "contraction in code",
+			content: "<pre><code>echo \"didn't\"</code></pre>",
+		},
+		{
+			name:     "contraction split by markup",
+			content:  "<p>The hosts <em>didn't</em> require it.</p>",
+			expected: []string{"PT101"},
+		},
+		{
+			name:     "caption period",
+			content:  "<figure><img src=\"a.png\"><figcaption>Relayed hashes.</figcaption></figure>",
+			expected: []string{"PT102"},
+		},
+		{
+			name:     "tool name case",
+			content:  "<p>netexec and certipy were used.</p>",
+			expected: []string{"PT103", "PT103"},
+		},
+		{
+			name:    "tool names in code and image paths",
+			content: "<pre><code>certipy find\nnetexec smb</code></pre><p><img src=\"/uploads/netexec.png\"></p>",
+		},
+		{
+			name:     "secret",
+			content:  "<pre><code>Administrator:500:aad3b435b51404eeaad3b435b51404ee:31d6cfe0d16ae931b73c59d7e0c089c0:::</code></pre>",
+			expected: []string{"PT104"},
+		},
+		{
+			name:     "spelling",
+			content:  "<p>The hosts were compromized.</p><pre><code>compromized</code></pre>",
+			expected: []string{"PT105"},
+		},
+		{
+			name:     "suppressed by comment",
+			content:  "<!-- lint-ignore PT101 narrative-tool-name-case --><p>netexec didn't work.</p>",
+			expected: nil,
+		},
+		{
+			name:     "suppressed for some rules",
+			content:  "<!-- lint-ignore contraction --><p>netexec didn't work.</p>",
+			expected: []string{"PT103"},
+		},
+		{
+			name:     "everything suppressed",
+			content:  "<!--lint-ignore--><p>netexec didn't work.</p>",
+			expected: nil,
+		},
+	}
+
+	l := newLinter(t, types.LintConfig{})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			issues := l.Sections([]plextrac.Section{{ID: "1", Title: "Summary", Content: tt.content}})
+
+			got := ruleIDs(issues)
+			if !slices.Equal(got, tt.expected) {
+				t.Errorf("rules = %v, want %v", got, tt.expected)
+			}
+
+			for _, i := range issues {
+				if i.Section != "Summary" || i.ObjectID != "1" || i.Scope != lint.ScopeSection {
+					t.Errorf("issue has the wrong location: %+v", i)
+				}
+			}
+		})
+	}
+}
+
+func TestLinter_Sections_severity(t *testing.T) {
+	t.Parallel()
+
+	l := newLinter(t, types.LintConfig{
+		Severity: map[string]string{"contraction": "info"},
+	})
+
+	issues := l.Sections([]plextrac.Section{{Title: "Summary", Content: "<p>It didn't.</p>"}})
+	if len(issues) != 1 {
+		t.Fatalf("expected one issue, got %v", issues)
+	}
+
+	if issues[0].Severity != lint.Info {
+		t.Errorf("expected the configured severity, got %s", issues[0].Severity)
+	}
+
+	if issues[0].Snippet != "It didn't." {
+		t.Errorf("expected the contraction as the snippet, got %q", issues[0].Snippet)
+	}
+}
+
+func TestLinter_FixSection(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+		rules    []string
+	}{
+		{
+			name:     "clean",
+			content:  "<p>NetExec was used.</p>",
+			expected: "<p>NetExec was used.</p>",
+		},
+		{
+			name:     "contractions",
+			content:  "<p>The hosts didn't require signing, so we can't say it won't work. DON'T</p>",
+			expected: "<p>The hosts did not require signing, so we cannot say it will not work. DO NOT</p>",
+			rules:    []string{"PT101"},
+		},
+		{
+			name:     "caption period",
+			content:  "<figure><img src=\"a.png\"><figcaption>Relayed hashes.</figcaption></figure>",
+			expected: "<figure><img src=\"a.png\"><figcaption>Relayed hashes</figcaption></figure>",
+			rules:    []string{"PT102"},
+		},
+		{
+			name:     "tool name case outside code",
+			content:  "<p>netexec &amp; certipy were used.</p><pre><code>netexec smb 10.0.0.1</code></pre>",
+			expected: "<p>NetExec &amp; Certipy were used.</p><pre><code>netexec smb 10.0.0.1</code></pre>",
+			rules:    []string{"PT103"},
+		},
+		{
+			name:     "suppressed",
+			content:  "<!-- lint-ignore contraction --><p>netexec didn't work.</p>",
+			expected: "<!-- lint-ignore contraction --><p>NetExec didn't work.</p>",
+			rules:    []string{"PT103"},
+		},
+	}
+
+	l := newLinter(t, types.LintConfig{})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, rules := l.FixSection(nil, plextrac.Section{Title: "Summary", Content: tt.content})
+			if got != tt.expected {
+				t.Errorf("FixSection() = %q, want %q", got, tt.expected)
+			}
+
+			var ids []string
+			for _, r := range rules {
+				ids = append(ids, r.ID)
+			}
+
+			if !slices.Equal(ids, tt.rules) {
+				t.Errorf("rules = %v, want %v", ids, tt.rules)
+			}
+		})
+	}
+}
+
+func TestLinter_Writeups(t *testing.T) {
+	t.Parallel()
+
+	dictionary := filepath.Join(t.TempDir(), "dictionary.txt")
+
+	err := os.WriteFile(dictionary, []byte("kerbrutal\n"), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l := newLinter(t, types.LintConfig{
+		Dictionary: dictionary,
+		Words:      []string{"nxcx"},
+	})
+
+	issues := l.Writeups([]*plextrac.Writeup{
+		{ID: "1", Title: "SMB Signing Not Requred", Description: "<p>Kerbrutal and nxcx were used.</p>"},
+		{ID: "2", Title: "Weak Passwords", Recommendations: "<p>Use a passphrase mangaer.</p>"},
+		{ID: "3", Title: "Ignored Writeup", Description: "<p>Mispelled</p>", Tags: []string{"lint-ignore-writeup-spelling"}},
+	})
+
+	var got []string
+	for _, i := range issues {
+		if i.Scope != lint.ScopeWriteup || i.Rule.ID != "PT301" {
+			t.Errorf("unexpected issue %+v", i)
+		}
+
+		got = append(got, i.ObjectID+" "+i.Snippet)
+	}
+
+	expected := []string{"1 Requred", "2 mangaer"}
+	if !slices.Equal(got, expected) {
+		t.Errorf("issues = %v, want %v", got, expected)
+	}
+
+	if issues[0].Message != `writeup "SMB Signing Not Requred" title has a misspelling: "Requred", did you mean "Required", "Returned", "Refused"?` {
+		t.Errorf("message = %s", issues[0].Message)
+	}
+}
@@ -0,0 +1,244 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/brimstone/plextraccli/version"
+)
+
+// Formats are the formats lint results can be written in.
+var Formats = []string{"text", "json", "sarif"}
+
+// MarshalText writes the severity by name, for JSON.
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText reads the severity by name.
+func (s *Severity) UnmarshalText(text []byte) error {
+	severity, err := ParseSeverity(string(text))
+	if err != nil {
+		return err
+	}
+
+	*s = severity
+
+	return nil
+}
+
+// result is an Issue as written in JSON.
+type result struct {
+	Rule       string   `json:"rule"`
+	RuleName   string   `json:"rule_name"`
+	Severity   Severity `json:"severity"`
+	ObjectType Scope    `json:"object_type"`
+	ObjectID   string   `json:"object_id"`
+	Object     string   `json:"object"`
+	Section    string   `json:"section,omitempty"`
+	Message    string   `json:"message"`
+	Snippet    string   `json:"snippet,omitempty"`
+}
+
+// AtLeast returns the issues at or above the severity.
+func AtLeast(issues []Issue, severity Severity) []Issue {
+	return slices.DeleteFunc(slices.Clone(issues), func(i Issue) bool {
+		return i.Severity < severity
+	})
+}
+
+// Write writes the issues in one of the Formats.
+func Write(w io.Writer, format string, issues []Issue) error {
+	switch format {
+	case "text":
+		return writeText(w, issues)
+	case "json":
+		return writeJSON(w, issues)
+	case "sarif":
+		return writeSARIF(w, issues)
+	}
+
+	return fmt.Errorf("unknown format %q, must be one of: %s", format, strings.Join(Formats, ", "))
+}
+
+func writeText(w io.Writer, issues []Issue) error {
+	for _, heading := range []struct {
+		title  string
+		scopes []Scope
+	}{
+		{"Issues with report", []Scope{ScopeReport, ScopeSection}},
+		{"Issues with findings", []Scope{ScopeFinding}},
+		{"Issues with writeups", []Scope{ScopeWriteup}},
+	} {
+		printed := false
+
+		for _, i := range issues {
+			if !slices.Contains(heading.scopes, i.Scope) {
+				continue
+			}
+
+			if !printed {
+				_, err := fmt.Fprintf(w, "%s:\n", heading.title)
+				if err != nil {
+					return err
+				}
+
+				printed = true
+			}
+
+			_, err := fmt.Fprintf(w, "- %s %s: %s\n", i.Severity, i.Rule, i.Message)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func writeJSON(w io.Writer, issues []Issue) error {
+	results := []result{}
+
+	for _, i := range issues {
+		results = append(results, result{
+			Rule:       i.Rule.ID,
+			RuleName:   i.Rule.Name,
+			Severity:   i.Severity,
+			ObjectType: i.Scope,
+			ObjectID:   i.ObjectID,
+			Object:     i.Object,
+			Section:    i.Section,
+			Message:    i.Message,
+			Snippet:    i.Snippet,
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(results)
+}
+
+// sarifLevels are the SARIF levels of the severities.
+var sarifLevels = map[Severity]string{
+	Info:    "note",
+	Warning: "warning",
+	Error:   "error",
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifRule struct {
+	ID                   string       `json:"id"`
+	Name                 string       `json:"name"`
+	ShortDescription     sarifMessage `json:"shortDescription"`
+	DefaultConfiguration struct {
+		Level string `json:"level"`
+	} `json:"defaultConfiguration"`
+}
+
+type sarifLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+type sarifLocation struct {
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifResult struct {
+	RuleID     string            `json:"ruleId"`
+	RuleIndex  int               `json:"ruleIndex"`
+	Level      string            `json:"level"`
+	Message    sarifMessage      `json:"message"`
+	Locations  []sarifLocation   `json:"locations"`
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+// writeSARIF writes the issues as SARIF 2.1.0, for code scanning tools.
+// PlexTrac objects have no files, so issues are located by logical
+// locations, eg: finding/123.
+func writeSARIF(w io.Writer, issues []Issue) error {
+	rules := []sarifRule{}
+
+	var ruleIDs []string
+
+	results := []sarifResult{}
+
+	for _, i := range issues {
+		index := slices.Index(ruleIDs, i.Rule.ID)
+		if index == -1 {
+			rule := sarifRule{
+				ID:               i.Rule.ID,
+				Name:             i.Rule.Name,
+				ShortDescription: sarifMessage{Text: i.Rule.Description},
+			}
+			rule.DefaultConfiguration.Level = sarifLevels[i.Rule.Severity]
+
+			index = len(rules)
+			rules = append(rules, rule)
+			ruleIDs = append(ruleIDs, i.Rule.ID)
+		}
+
+		r := sarifResult{
+			RuleID:    i.Rule.ID,
+			RuleIndex: index,
+			Level:     sarifLevels[i.Severity],
+			Message:   sarifMessage{Text: i.Message},
+			Properties: map[string]string{
+				"objectType": string(i.Scope),
+				"objectId":   i.ObjectID,
+				"object":     i.Object,
+			},
+		}
+
+		if i.Section != "" {
+			r.Properties["section"] = i.Section
+		}
+
+		if i.Snippet != "" {
+			r.Properties["snippet"] = i.Snippet
+		}
+
+		r.Locations = []sarifLocation{{
+			LogicalLocations: []sarifLogicalLocation{{
+				Name:               i.Object,
+				FullyQualifiedName: string(i.Scope) + "/" + i.ObjectID,
+				Kind:               "object",
+			}},
+		}}
+
+		results = append(results, r)
+	}
+
+	log := map[string]any{
+		"$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+		"version": "2.1.0",
+		"runs": []any{
+			map[string]any{
+				"tool": map[string]any{
+					"driver": map[string]any{
+						"name":           "plextraccli",
+						"version":        version.Version,
+						"informationUri": "https://github.com/brimstone/plextraccli",
+						"rules":          rules,
+					},
+				},
+				"results": results,
+			},
+		},
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(log)
+}
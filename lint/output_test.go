@@ -0,0 +1,220 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package lint_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/brimstone/plextraccli/lint"
+)
+
+func testIssues(t *testing.T) []lint.Issue {
+	t.Helper()
+
+	return []lint.Issue{
+		{
+			Rule:     ruleByID(t, "PT004"),
+			Severity: lint.Warning,
+			Scope:    lint.ScopeReport,
+			ObjectID: "456",
+			Object:   "Test Report",
+			Message:  "report is still in draft",
+		},
+		{
+			Rule:     ruleByID(t, "PT101"),
+			Severity: lint.Info,
+			Scope:    lint.ScopeSection,
+			ObjectID: "exec",
+			Object:   "Summary",
+			Section:  "Summary",
+			Message:  `found contraction in section "Summary": "It didn't."`,
+			Snippet:  "It didn't.",
+		},
+		{
+			Rule:     ruleByID(t, "PT202"),
+			Severity: lint.Error,
+			Scope:    lint.ScopeFinding,
+			ObjectID: "789",
+			Object:   "SMB Signing Not Required",
+			Message:  `finding "SMB Signing Not Required" has no evidence`,
+		},
+	}
+}
+
+func TestAtLeast(t *testing.T) {
+	t.Parallel()
+
+	issues := testIssues(t)
+
+	for severity, expected := range map[lint.Severity]int{
+		lint.Info:    3,
+		lint.Warning: 2,
+		lint.Error:   1,
+	} {
+		if got := len(lint.AtLeast(issues, severity)); got != expected {
+			t.Errorf("AtLeast(%s) returned %d issues, want %d", severity, got, expected)
+		}
+	}
+
+	if len(issues) != 3 {
+		t.Error("AtLeast() changed the issues it was given")
+	}
+}
+
+func TestWrite_text(t *testing.T) {
+	t.Parallel()
+
+	var b bytes.Buffer
+
+	err := lint.Write(&b, "text", testIssues(t))
+	if err != nil {
+		t.Fatalf("Write() returned error: %v", err)
+	}
+
+	expected := "Issues with report:\n" +
+		"- warning PT004 report-draft: report is still in draft\n" +
+		"- info PT101 contraction: found contraction in section \"Summary\": \"It didn't.\"\n" +
+		"Issues with findings:\n" +
+		"- error PT202 no-evidence: finding \"SMB Signing Not Required\" has no evidence\n"
+	if b.String() != expected {
+		t.Errorf("Write() = %q, want %q", b.String(), expected)
+	}
+}
+
+func TestWrite_json(t *testing.T) {
+	t.Parallel()
+
+	var b bytes.Buffer
+
+	err := lint.Write(&b, "json", testIssues(t))
+	if err != nil {
+		t.Fatalf("Write() returned error: %v", err)
+	}
+
+	var results []map[string]string
+
+	err = json.Unmarshal(b.Bytes(), &results)
+	if err != nil {
+		t.Fatalf("unable to parse output: %v\n%s", err, b.String())
+	}
+
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+
+	expected := map[string]string{
+		"rule":        "PT101",
+		"rule_name":   "contraction",
+		"severity":    "info",
+		"object_type": "section",
+		"object_id":   "exec",
+		"object":      "Summary",
+		"section":     "Summary",
+		"message":     `found contraction in section "Summary": "It didn't."`,
+		"snippet":     "It didn't.",
+	}
+	for k, v := range expected {
+		if results[1][k] != v {
+			t.Errorf("%s = %q, want %q", k, results[1][k], v)
+		}
+	}
+}
+
+func TestWrite_json_no_issues(t *testing.T) {
+	t.Parallel()
+
+	var b bytes.Buffer
+
+	err := lint.Write(&b, "json", nil)
+	if err != nil {
+		t.Fatalf("Write() returned error: %v", err)
+	}
+
+	if strings.TrimSpace(b.String()) != "[]" {
+		t.Errorf("expected an empty list, got %q", b.String())
+	}
+}
+
+func TestWrite_sarif(t *testing.T) {
+	t.Parallel()
+
+	var b bytes.Buffer
+
+	err := lint.Write(&b, "sarif", testIssues(t))
+	if err != nil {
+		t.Fatalf("Write() returned error: %v", err)
+	}
+
+	var log struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Name  string `json:"name"`
+					Rules []struct {
+						ID string `json:"id"`
+					} `json:"rules"`
+				} `json:"driver"`
+			} `json:"tool"`
+			Results []struct {
+				RuleID    string `json:"ruleId"`
+				RuleIndex int    `json:"ruleIndex"`
+				Level     string `json:"level"`
+				Locations []struct {
+					LogicalLocations []struct {
+						FullyQualifiedName string `json:"fullyQualifiedName"`
+					} `json:"logicalLocations"`
+				} `json:"locations"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+
+	err = json.Unmarshal(b.Bytes(), &log)
+	if err != nil {
+		t.Fatalf("unable to parse output: %v\n%s", err, b.String())
+	}
+
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("expected one SARIF 2.1.0 run, got %s", b.String())
+	}
+
+	run := log.Runs[0]
+	if len(run.Tool.Driver.Rules) != 3 || len(run.Results) != 3 {
+		t.Fatalf("expected 3 rules and results, got %s", b.String())
+	}
+
+	for i, expected := range []struct {
+		level    string
+		location string
+	}{
+		{"warning", "report/456"},
+		{"note", "section/exec"},
+		{"error", "finding/789"},
+	} {
+		r := run.Results[i]
+
+		if r.Level != expected.level {
+			t.Errorf("result %d level = %q, want %q", i, r.Level, expected.level)
+		}
+
+		if run.Tool.Driver.Rules[r.RuleIndex].ID != r.RuleID {
+			t.Errorf("result %d points at rule %d, not %s", i, r.RuleIndex, r.RuleID)
+		}
+
+		if got := r.Locations[0].LogicalLocations[0].FullyQualifiedName; got != expected.location {
+			t.Errorf("result %d location = %q, want %q", i, got, expected.location)
+		}
+	}
+}
+
+func TestWrite_unknown_format(t *testing.T) {
+	t.Parallel()
+
+	err := lint.Write(&bytes.Buffer{}, "xml", nil)
+	if err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package lint
+
+import (
+	"slices"
+
+	"github.com/brimstone/plextraccli/plextrac"
+)
+
+var reportRules = []*Rule{
+	{
+		ID:          "PT001",
+		Name:        "missing-stop-date",
+		Severity:    Error,
+		Scope:       ScopeReport,
+		Description: "The report has no stop date",
+		Report: func(l *Linter, r *plextrac.Report) []Problem {
+			if r.StopDate.IsZero() {
+				return []Problem{problem("", "report is missing Stop Date")}
+			}
+
+			return nil
+		},
+	},
+	{
+		ID:          "PT002",
+		Name:        "missing-start-date",
+		Severity:    Error,
+		Scope:       ScopeReport,
+		Description: "The report has no start date",
+		Report: func(l *Linter, r *plextrac.Report) []Problem {
+			if r.StartDate.IsZero() {
+				return []Problem{problem("", "report is missing Start Date")}
+			}
+
+			return nil
+		},
+	},
+	{
+		ID:          "PT003",
+		Name:        "start-after-stop",
+		Severity:    Error,
+		Scope:       ScopeReport,
+		Description: "The report starts after it stops",
+		Report: func(l *Linter, r *plextrac.Report) []Problem {
+			if r.StartDate.After(r.StopDate) && !r.StopDate.IsZero() {
+				return []Problem{problem("", "start date is after Stop date")}
+			}
+
+			return nil
+		},
+	},
+	{
+		ID:          "PT004",
+		Name:        "report-draft",
+		Severity:    Warning,
+		Scope:       ScopeReport,
+		Description: "The report is still a draft",
+		Report: func(l *Linter, r *plextrac.Report) []Problem {
+			if r.Status == "Draft" {
+				return []Problem{problem("", "report is still in draft")}
+			}
+
+			return nil
+		},
+	},
+	{
+		ID:          "PT005",
+		Name:        "report-no-tags",
+		Severity:    Warning,
+		Scope:       ScopeReport,
+		Description: "The report has no tags, so no required sections",
+		Report: func(l *Linter, r *plextrac.Report) []Problem {
+			if !slices.ContainsFunc(r.Tags(), func(t string) bool { return !isIgnoreTag(t) }) {
+				return []Problem{problem("", "report has no tags")}
+			}
+
+			return nil
+		},
+	},
+	{
+		ID:          "PT006",
+		Name:        "unknown-tag",
+		Severity:    Warning,
+		Scope:       ScopeReport,
+		Description: "The report has a tag that isn't used by any required section in the config",
+		Report: func(l *Linter, r *plextrac.Report) []Problem {
+			if len(l.Config.RequiredSections) == 0 {
+				return nil
+			}
+
+			knownTags := make(map[string]bool)
+
+			for _, rs := range l.Config.RequiredSections {
+				for _, tag := range rs.Tags {
+					knownTags[tag] = true
+				}
+			}
+
+			var problems []Problem
+
+			for _, t := range r.Tags() {
+				if !knownTags[t] && !isIgnoreTag(t) {
+					problems = append(problems, problem(t, "report uses unknown tag: %s", t))
+				}
+			}
+
+			return problems
+		},
+	},
+	{
+		ID:          "PT007",
+		Name:        "missing-section",
+		Severity:    Error,
+		Scope:       ScopeReport,
+		Description: "The report is missing a section required for its tags by the config",
+		Report: func(l *Linter, r *plextrac.Report) []Problem {
+			missing, _ := sectionsByRequirement(l, r)
+
+			var problems []Problem
+			for _, s := range missing {
+				problems = append(problems, problem(s, "missing section: %s", s))
+			}
+
+			return problems
+		},
+	},
+	{
+		ID:          "PT008",
+		Name:        "extra-section",
+		Severity:    Warning,
+		Scope:       ScopeReport,
+		Description: "The report has a section that isn't required for its tags by the config",
+		Report: func(l *Linter, r *plextrac.Report) []Problem {
+			if len(l.Config.RequiredSections) == 0 {
+				return nil
+			}
+
+			_, extra := sectionsByRequirement(l, r)
+
+			var problems []Problem
+			for _, s := range extra {
+				problems = append(problems, problem(s, "extra section: %s", s))
+			}
+
+			return problems
+		},
+	},
+}
+
+// sectionsByRequirement compares the sections of the report to the ones the
+// config requires for its tags, returning the missing and extra sections.
+func sectionsByRequirement(l *Linter, r *plextrac.Report) ([]string, []string) {
+	sections, _, err := r.Sections()
+	if err != nil {
+		return nil, nil
+	}
+
+	var titles []string
+	for _, s := range sections {
+		titles = append(titles, s.Title)
+	}
+
+	var requiredSections []string
+
+	for _, rs := range l.Config.RequiredSections {
+		if len(rs.Tags) == 0 {
+			requiredSections = append(requiredSections, rs.Section)
+
+			continue
+		}
+
+		for _, t := range r.Tags() {
+			if slices.Contains(rs.Tags, t) {
+				requiredSections = append(requiredSections, rs.Section)
+			}
+		}
+	}
+
+	var missing []string
+
+	for _, s := range requiredSections {
+		j := slices.Index(titles, s)
+		if j == -1 {
+			missing = append(missing, s)
+		} else {
+			titles = slices.Delete(titles, j, j+1)
+		}
+	}
+
+	return missing, titles
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package lint
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/brimstone/plextraccli/plextrac"
+)
+
+// Severity is how much an issue matters.
+type Severity int
+
+const (
+	Info Severity = iota
+	Warning
+	Error
+)
+
+var severityNames = []string{"info", "warning", "error"}
+
+func (s Severity) String() string {
+	if int(s) < 0 || int(s) >= len(severityNames) {
+		return fmt.Sprintf("severity(%d)", int(s))
+	}
+
+	return severityNames[s]
+}
+
+// ParseSeverity parses the name of a severity, ignoring case.
+func ParseSeverity(name string) (Severity, error) {
+	i := slices.Index(severityNames, strings.ToLower(strings.TrimSpace(name)))
+	if i == -1 {
+		return Info, fmt.Errorf("unknown severity %q, must be one of: %s", name, strings.Join(severityNames, ", "))
+	}
+
+	return Severity(i), nil
+}
+
+// Scope is what a rule checks. Grammar and custom rules check more than one,
+// and their scopes are joined by commas, eg: section,finding
+type Scope string
+
+const (
+	ScopeReport  Scope = "report"
+	ScopeSection Scope = "section"
+	ScopeFinding Scope = "finding"
+	ScopeWriteup Scope = "writeup"
+)
+
+// Problem is what a rule found, before the linter knows which rule found it
+// and how much it matters.
+type Problem struct {
+	Message string
+	// Snippet is the text the problem is about, if there is any
+	Snippet string
+}
+
+// Rule is a check the linter runs. Report rules check the report, section
+// rules its narratives, finding rules its findings and writeup rules the
+// writeups in the content library. Grammar and custom rules can check both
+// narratives and findings.
+type Rule struct {
+	// ID is short and stable, eg: PT001
+	ID string
+	// Name says what the rule finds, eg: missing-stop-date
+	Name        string
+	Severity    Severity
+	Scope       Scope
+	Description string
+	// Off rules only run when enabled in the config
+	Off bool
+
+	Report  func(l *Linter, r *plextrac.Report) []Problem
+	Section func(l *Linter, s plextrac.Section) []Problem
+	Finding func(l *Linter, f *plextrac.Finding) []Problem
+	Writeup func(l *Linter, w *plextrac.Writeup) []Problem
+
+	// Fix rewrites the content of a section, or the evidence of a finding,
+	// with the title given, so the rule no longer finds anything, for rules
+	// where that's safe
+	Fix func(l *Linter, scope Scope, title string, content string) string
+}
+
+// String is how the rule is referred to, eg: PT001 missing-stop-date
+func (r *Rule) String() string {
+	return r.ID + " " + r.Name
+}
+
+// Is reports whether the rule goes by the ID or name, ignoring case.
+func (r *Rule) Is(idOrName string) bool {
+	return strings.EqualFold(r.ID, idOrName) || strings.EqualFold(r.Name, idOrName)
+}
+
+// registry is every known rule, in the order they're run and listed.
+var registry = slices.Concat(reportRules, sectionRules, findingRules, writeupRules, grammarRules)
+
+// Rules returns every known rule.
+func Rules() []*Rule {
+	return registry
+}
+
+// problem makes a Problem out of a format string.
+func problem(snippet string, format string, args ...any) Problem {
+	return Problem{
+		Message: fmt.Sprintf(format, args...),
+		Snippet: snippet,
+	}
+}
@@ -0,0 +1,215 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/brimstone/plextraccli/plextrac"
+	"github.com/brimstone/plextraccli/richtext"
+)
+
+var toolNames = []string{"MitM6", "NTLMRelayX", "Certipy", "NetExec"}
+
+var contraction = regexp.MustCompile(`.{0,10}n['’]t.{0,10}`)
+
+var captionPeriod = regexp.MustCompile(`<figcaption>([^<]*\.)\s*</figcaption>`)
+
+// expandable are the contractions fixContractions knows how to expand.
+var expandable = regexp.MustCompile(`(?i)\b(ca|wo|sha|do|does|did|is|are|was|were|has|have|had|should|could|would|must|need)n['’]t\b`)
+
+var sectionRules = []*Rule{
+	{
+		ID:          "PT101",
+		Name:        "contraction",
+		Severity:    Warning,
+		Scope:       ScopeSection,
+		Description: "A narrative uses a contraction such as don't",
+		Section: func(l *Linter, s plextrac.Section) []Problem {
+			var problems []Problem
+			for _, m := range contractions(s.Content) {
+				problems = append(problems, problem(m, "found contraction in section %q: %q", s.Title, m))
+			}
+
+			return problems
+		},
+		Fix: func(l *Linter, scope Scope, title string, content string) string {
+			return fixContractions(content)
+		},
+	},
+	{
+		ID:          "PT102",
+		Name:        "narrative-caption-period",
+		Severity:    Warning,
+		Scope:       ScopeSection,
+		Description: "A narrative has a caption ending with a period",
+		Section: func(l *Linter, s plextrac.Section) []Problem {
+			var problems []Problem
+			for _, caption := range captionsWithPeriods(s.Content) {
+				problems = append(problems, problem(caption, "narrative %q has a caption ending with a period", s.Title))
+			}
+
+			return problems
+		},
+		Fix: func(l *Linter, scope Scope, title string, content string) string {
+			return fixCaptionPeriods(content)
+		},
+	},
+	{
+		ID:          "PT103",
+		Name:        "narrative-tool-name-case",
+		Severity:    Warning,
+		Scope:       ScopeSection,
+		Description: "A narrative has a tool name with the wrong case, such as netexec for NetExec",
+		Section: func(l *Linter, s plextrac.Section) []Problem {
+			var problems []Problem
+			for _, p := range checkCapitalization(prose(s.Content), l.toolNames) {
+				p.Message = fmt.Sprintf("narrative %q has a misspelling: %s", s.Title, p.Message)
+				problems = append(problems, p)
+			}
+
+			return problems
+		},
+		Fix: func(l *Linter, scope Scope, title string, content string) string {
+			return fixCapitalization(content, l.toolNames)
+		},
+	},
+	{
+		ID:          "PT104",
+		Name:        "narrative-secret",
+		Severity:    Error,
+		Scope:       ScopeSection,
+		Description: "A narrative has a secret, such as an NTLM hash, that the redact command would mask",
+		Section: func(l *Linter, s plextrac.Section) []Problem {
+			if l.Redactor == nil {
+				return nil
+			}
+
+			var problems []Problem
+			for _, m := range l.Redactor.Find(s.Content) {
+				problems = append(problems, problem(m.Preview(), "narrative %q has a %s secret", s.Title, m.Detector))
+			}
+
+			return problems
+		},
+	},
+	{
+		ID:          "PT105",
+		Name:        "narrative-spelling",
+		Severity:    Warning,
+		Scope:       ScopeSection,
+		Description: "A narrative has a word that isn't in the dictionary",
+		Section: func(l *Linter, s plextrac.Section) []Problem {
+			return checkSpelling(l, s.Content, fmt.Sprintf("narrative %q", s.Title))
+		},
+	},
+}
+
+// captionsWithPeriods returns the captions in the content that end with a
+// period.
+func captionsWithPeriods(content string) []string {
+	var captions []string
+	for _, m := range captionPeriod.FindAllStringSubmatch(content, -1) {
+		captions = append(captions, m[1])
+	}
+
+	return captions
+}
+
+// checkCapitalization finds the words in the content that are spelled with
+// the wrong case.
+func checkCapitalization(content string, words []string) []Problem {
+	var problems []Problem
+
+	for _, word := range words {
+		escapedWord := regexp.QuoteMeta(word)
+		pattern := `(?i)\b` + escapedWord + `\b` // Case-insensitive whole-word match
+
+		regex, err := regexp.Compile(pattern)
+		if err != nil {
+			problems = append(problems, problem(word, "%s", err))
+
+			continue
+		}
+
+		for _, match := range regex.FindAllString(content, -1) {
+			// Check if the exact (original) case of the word is present
+			if match != word {
+				problems = append(problems, problem(match, "%q has the wrong case", word))
+			}
+		}
+	}
+
+	return problems
+}
+
+// fixContractions expands the common contractions in the prose, eg: didn't
+// becomes did not, keeping the case of the first letter.
+func fixContractions(content string) string {
+	return richtext.MapText(content, func(text string) string {
+		return expandable.ReplaceAllStringFunc(text, func(m string) string {
+			word := expandable.FindStringSubmatch(m)[1]
+
+			var expanded string
+
+			switch strings.ToLower(word) {
+			case "ca":
+				expanded = word + "nnot"
+			case "wo":
+				expanded = word[:1] + "ill not"
+			case "sha":
+				expanded = word + "ll not"
+			default:
+				expanded = word + " not"
+			}
+
+			if strings.ToUpper(m) == m {
+				return strings.ToUpper(expanded)
+			}
+
+			return expanded
+		})
+	})
+}
+
+// contractions finds the contractions in the prose, the same text
+// fixContractions expands, with some context around each.
+func contractions(content string) []string {
+	var found []string
+
+	richtext.MapText(content, func(text string) string {
+		found = append(found, contraction.FindAllString(text, -1)...)
+
+		return text
+	})
+
+	return found
+}
+
+// fixCaptionPeriods removes the periods from the end of captions.
+func fixCaptionPeriods(content string) string {
+	return captionPeriod.ReplaceAllStringFunc(content, func(m string) string {
+		caption := captionPeriod.FindStringSubmatch(m)[1]
+
+		return "<figcaption>" + strings.TrimRight(caption, ". ") + "</figcaption>"
+	})
+}
+
+// fixCapitalization spells the words with the right case in the prose,
+// leaving code alone, where the lowercase name is probably the command.
+func fixCapitalization(content string, words []string) string {
+	return richtext.MapText(content, func(text string) string {
+		for _, word := range words {
+			re, err := regexp.Compile(`(?i)\b` + regexp.QuoteMeta(word) + `\b`)
+			if err != nil {
+				continue
+			}
+
+			text = re.ReplaceAllLiteralString(text, word)
+		}
+
+		return text
+	})
+}
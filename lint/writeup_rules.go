@@ -0,0 +1,124 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package lint
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/brimstone/plextraccli/cvss"
+	"github.com/brimstone/plextraccli/plextrac"
+)
+
+var writeupRules = []*Rule{
+	{
+		ID:          "PT301",
+		Name:        "writeup-spelling",
+		Severity:    Warning,
+		Scope:       ScopeWriteup,
+		Description: "A writeup's title, description or recommendations has a word that isn't in the dictionary",
+		Writeup: func(l *Linter, w *plextrac.Writeup) []Problem {
+			where := fmt.Sprintf("writeup %q", w.Title)
+
+			return slices.Concat(
+				checkSpelling(l, w.Title, where+" title"),
+				checkSpelling(l, w.Description, where+" description"),
+				checkSpelling(l, w.Recommendations, where+" recommendations"),
+			)
+		},
+	},
+	{
+		ID:          "PT302",
+		Name:        "writeup-cvss-severity",
+		Severity:    Warning,
+		Scope:       ScopeWriteup,
+		Description: "A writeup's severity doesn't match the rating of its CVSS v3 base score",
+		Writeup: func(l *Linter, w *plextrac.Writeup) []Problem {
+			return checkCVSSSeverity(fmt.Sprintf("writeup %q", w.Title), w.Severity, w.Fields.Scores.Cvss3.Calculation)
+		},
+	},
+	{
+		ID:          "PT303",
+		Name:        "writeup-bad-cvss",
+		Severity:    Error,
+		Scope:       ScopeWriteup,
+		Description: "A writeup's CVSS v3 vector isn't valid",
+		Writeup: func(l *Linter, w *plextrac.Writeup) []Problem {
+			return checkCVSSVector(fmt.Sprintf("writeup %q", w.Title), w.Fields.Scores.Cvss3.Calculation)
+		},
+	},
+}
+
+// checkSpelling finds the misspelled words in the prose of the content,
+// describing them after where they are, eg: narrative "Summary".
+func checkSpelling(l *Linter, content string, where string) []Problem {
+	if l.Dictionary == nil {
+		return nil
+	}
+
+	var problems []Problem
+
+	for _, m := range l.Dictionary.Check(prose(content)) {
+		message := fmt.Sprintf("%s has a misspelling: %q", where, m.Word)
+
+		if m.Count > 1 {
+			message += fmt.Sprintf(" (%d times)", m.Count)
+		}
+
+		if len(m.Suggestions) > 0 {
+			var quoted []string
+			for _, s := range m.Suggestions {
+				quoted = append(quoted, fmt.Sprintf("%q", s))
+			}
+
+			message += ", did you mean " + strings.Join(quoted, ", ") + "?"
+		}
+
+		problems = append(problems, problem(m.Word, "%s", message))
+	}
+
+	return problems
+}
+
+// checkCVSSSeverity finds a severity that isn't the rating of the base score
+// of the CVSS vector, describing it after what has it, eg: finding "SMB
+// Signing". Informational is the same as a rating of None, and vectors that
+// aren't valid are left to checkCVSSVector.
+func checkCVSSSeverity(where string, severity string, vector string) []Problem {
+	if vector == "" || severity == "" {
+		return nil
+	}
+
+	v, err := cvss.Parse(vector)
+	if err != nil {
+		return nil
+	}
+
+	score := v.BaseScore()
+
+	rating := cvss.Rating(score)
+	if rating == "None" {
+		rating = "Informational"
+	}
+
+	if strings.EqualFold(severity, rating) {
+		return nil
+	}
+
+	return []Problem{problem(vector, "%s is %s, but its CVSS %s base score of %.1f is %s", where, severity, v.Version, score, rating)}
+}
+
+// checkCVSSVector finds a CVSS vector that isn't valid.
+func checkCVSSVector(where string, vector string) []Problem {
+	if vector == "" {
+		return nil
+	}
+
+	_, err := cvss.Parse(vector)
+	if err != nil {
+		return []Problem{problem(vector, "%s has an invalid CVSS vector: %s", where, err)}
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package lint_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/brimstone/plextraccli/plextrac"
+	"github.com/brimstone/plextraccli/types"
+)
+
+func TestWriteupRules_cvss(t *testing.T) {
+	t.Parallel()
+
+	l := newLinter(t, types.LintConfig{})
+
+	tests := []struct {
+		name     string
+		severity string
+		vector   string
+		expected []string
+	}{
+		{
+			name:     "matches",
+			severity: "Critical",
+			vector:   "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H",
+		},
+		{
+			name:     "informational",
+			severity: "Informational",
+			vector:   "CVSS:3.1/AV:N/AC:H/PR:N/UI:N/S:U/C:N/I:N/A:N",
+		},
+		{
+			name:     "no vector",
+			severity: "High",
+		},
+		{
+			name:     "mismatch",
+			severity: "High",
+			vector:   "CVSS:3.1/AV:N/AC:L/PR:N/UI:R/S:C/C:L/I:L/A:N",
+			expected: []string{`writeup "XSS" is High, but its CVSS 3.1 base score of 6.1 is Medium`},
+		},
+		{
+			name:     "malformed",
+			severity: "High",
+			vector:   "CVSS:3.1/AV:N/AC:L/PR:N/UI:R/S:C/C:L/I:L",
+			expected: []string{`writeup "XSS" has an invalid CVSS vector: vector is missing metric A`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			w := &plextrac.Writeup{Title: "XSS", Severity: tt.severity}
+			w.Fields.Scores.Cvss3.Calculation = tt.vector
+
+			var messages []string
+
+			for _, id := range []string{"PT302", "PT303"} {
+				for _, p := range ruleByID(t, id).Writeup(l, w) {
+					messages = append(messages, p.Message)
+				}
+			}
+
+			if !slices.Equal(messages, tt.expected) {
+				t.Errorf("messages = %q, want %q", messages, tt.expected)
+			}
+		})
+	}
+}
@@ -15,9 +15,11 @@ import (
 	"github.com/brimstone/plextraccli/configure"
 	"github.com/brimstone/plextraccli/export"
 	"github.com/brimstone/plextraccli/findings"
+	"github.com/brimstone/plextraccli/imports"
 	"github.com/brimstone/plextraccli/lint"
 	"github.com/brimstone/plextraccli/mcp"
 	"github.com/brimstone/plextraccli/narratives"
+	"github.com/brimstone/plextraccli/redact"
 	"github.com/brimstone/plextraccli/reports"
 	"github.com/brimstone/plextraccli/tags"
 	"github.com/brimstone/plextraccli/update"
@@ -133,18 +135,7 @@ func main() {
 	}
 
 	err = rootCmd.RegisterFlagCompletionFunc("report", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
-		p, _, err := utils.NewPlextrac()
-		if err != nil {
-			// TODO something with err?
-			return nil, cobra.ShellCompDirectiveError
-		}
-
-		clientPartial := viper.GetString("client")
-		if clientPartial == "" {
-			return nil, cobra.ShellCompDirectiveError
-		}
-
-		c, err := p.ClientByPartial(clientPartial)
+		_, c, _, err := utils.GetClient()
 		if err != nil {
 			return nil, cobra.ShellCompDirectiveError
 		}
@@ -181,9 +172,11 @@ func main() {
 	rootCmd.AddCommand(configure.Cmd())
 	rootCmd.AddCommand(export.Cmd())
 	rootCmd.AddCommand(findings.Cmd())
+	rootCmd.AddCommand(imports.Cmd())
 	rootCmd.AddCommand(lint.Cmd())
 	rootCmd.AddCommand(mcp.Cmd())
 	rootCmd.AddCommand(narratives.Cmd())
+	rootCmd.AddCommand(redact.Cmd())
 	rootCmd.AddCommand(reports.Cmd())
 	rootCmd.AddCommand(tags.Cmd())
 	rootCmd.AddCommand(update.Cmd())
@@ -8,11 +8,11 @@ import (
 	"log/slog"
 	"strings"
 
-	htmltomarkdown "github.com/JohannesKaufmann/html-to-markdown/v2"
+	"github.com/brimstone/plextraccli/plextrac"
+	"github.com/brimstone/plextraccli/richtext"
 	"github.com/brimstone/plextraccli/utils"
 
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 )
 
 var formats = []string{
@@ -30,34 +30,131 @@ func Cmd() *cobra.Command {
 
 	cmd.PersistentFlags().StringP("type", "t", formats[0], "Format type. One of: "+strings.Join(formats, ",")+".")
 
+	// Edit subcommand
+	editCmd := &cobra.Command{
+		Use:   "edit <title>",
+		Short: "Edit a narrative section in $EDITOR as Markdown",
+		Args:  cobra.ExactArgs(1),
+		RunE:  cmdNarrativeEdit,
+	}
+	cmd.AddCommand(editCmd)
+
+	// Pull subcommand
+	pullCmd := &cobra.Command{
+		Use:   "pull [title]",
+		Short: "Download narrative sections along with their images",
+		Long: `Download narrative sections along with their images, for offline
+deliverables or archiving.
+
+Each section, or just the one matching title, is written to --dir as
+section-title.html or .md, depending on --type, with its images next to it
+as section-title-01.png and so on, and pointing at them.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: cmdNarrativePull,
+	}
+	pullCmd.Flags().String("dir", "narratives", "Directory to write to")
+	cmd.AddCommand(pullCmd)
+
 	return cmd
 }
 
-func cmdNarrative(cmd *cobra.Command, args []string) error {
-	// Get format
+func cmdNarrativePull(cmd *cobra.Command, args []string) error {
 	contentType := cmd.Flag("type").Value.String()
 
-	p, warnings, err := utils.NewPlextrac()
+	dir, err := cmd.Flags().GetString("dir")
+	if err != nil {
+		return err
+	}
+
+	p, r, warnings, err := utils.GetReport()
+	if err != nil {
+		return err
+	}
+
+	sections, warnings2, err := r.Sections()
+	if err != nil {
+		return err
+	}
+
+	warnings = append(warnings, warnings2...)
+
+	if len(args) == 1 {
+		section, warnings2, err := r.SectionByPartial(args[0])
+		if err != nil {
+			return err
+		}
+
+		warnings = append(warnings, warnings2...)
+		sections = []plextrac.Section{*section}
+	}
+
+	slugs := utils.Slugs{}
+
+	for _, s := range sections {
+		if s.Content == "" {
+			continue
+		}
+
+		filename, images, err := utils.PullRichText(p, s.Content, dir, slugs.Slug(s.Title), contentType)
+		if err != nil {
+			return fmt.Errorf("%s: %w", s.Title, err)
+		}
+
+		fmt.Printf("Wrote %s with %d images\n", filename, len(images))
+	}
+
+	for _, warning := range warnings {
+		slog.Warn("Warning while pulling narratives",
+			"warning", warning,
+		)
+	}
+
+	return nil
+}
+
+func cmdNarrativeEdit(cmd *cobra.Command, args []string) error {
+	_, r, warnings, err := utils.GetReport()
 	if err != nil {
 		return err
 	}
-	// Get Client
-	clientPartial := viper.GetString("client")
-	if clientPartial == "" {
-		return errors.New("must specify a client")
+
+	section, warnings2, err := r.SectionByPartial(args[0])
+	if err != nil {
+		return err
 	}
 
-	c, err := p.ClientByPartial(clientPartial)
+	warnings = append(warnings, warnings2...)
+
+	content, changed, err := utils.EditHTMLAsMarkdown(section.Content, section.Title)
 	if err != nil {
 		return err
 	}
-	// Get Report
-	reportPartial := viper.GetString("report")
-	if reportPartial == "" {
-		return errors.New("must specify a report")
+
+	if changed {
+		warnings2, err = r.SetSectionContent(section.ID, content)
+		if err != nil {
+			return err
+		}
+
+		warnings = append(warnings, warnings2...)
+
+		fmt.Printf("Updated %q\n", section.Title)
+	}
+
+	for _, warning := range warnings {
+		slog.Warn("Warning while editing narrative",
+			"warning", warning,
+		)
 	}
 
-	r, _, err := c.ReportByPartial(reportPartial)
+	return nil
+}
+
+func cmdNarrative(cmd *cobra.Command, args []string) error {
+	// Get format
+	contentType := cmd.Flag("type").Value.String()
+
+	_, r, warnings, err := utils.GetReport()
 	if err != nil {
 		return err
 	}
@@ -110,7 +207,7 @@ func cmdNarrative(cmd *cobra.Command, args []string) error {
 			case "html":
 				fmt.Printf("%s\n", content)
 			case "md":
-				md, err := htmltomarkdown.ConvertString(content)
+				md, err := richtext.ToMarkdown(content)
 				if err != nil {
 					return err
 				}
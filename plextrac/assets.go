@@ -5,12 +5,270 @@ package plextrac
 import (
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
 )
 
 type Asset struct {
-	ID    string
-	Value string
+	ID        string
+	Value     string
+	Ports     []AssetPort
+	Locations []string
+	Status    string
+	Notes     string
+}
+
+// AssetPort is a port an asset is affected on for a specific finding.
+type AssetPort struct {
+	Number   int
+	Protocol string
+	Service  string
+}
+
+func (p AssetPort) String() string {
+	s := strconv.Itoa(p.Number)
+
+	if p.Protocol != "" {
+		s += "/" + p.Protocol
+	}
+
+	if p.Service != "" {
+		s += " (" + p.Service + ")"
+	}
+
+	return s
+}
+
+// AssetSpec is an asset value along with the per finding details parsed
+// from it by ParseAssetSpec.
+type AssetSpec struct {
+	Value    string
+	Port     *AssetPort
+	Location string
+}
+
+// AssetChanges describes what happened to the assets of a finding.
+type AssetChanges struct {
+	Added     []Asset
+	Updated   []Asset
+	Removed   []Asset
+	Unchanged []Asset
+}
+
+var defaultPorts = map[string]int{
+	"http":  80,
+	"https": 443,
+	"ftp":   21,
+	"ssh":   22,
+	"ldap":  389,
+	"ldaps": 636,
+	"smb":   445,
+}
+
+// ParseAssetSpec parses an asset as given on the command line. Besides a
+// plain host name or address, it understands host:port, host:port/proto,
+// host:port/proto/service and URLs, which also become the location of the
+// asset.
+func ParseAssetSpec(s string) (AssetSpec, error) {
+	s = strings.TrimSpace(s)
+
+	if s == "" {
+		return AssetSpec{}, errors.New("empty asset")
+	}
+
+	// URLs
+	if strings.Contains(s, "://") {
+		u, err := url.Parse(s)
+		if err != nil {
+			return AssetSpec{}, fmt.Errorf("unable to parse url %q: %w", s, err)
+		}
+
+		if u.Hostname() == "" {
+			return AssetSpec{}, fmt.Errorf("url %q has no host", s)
+		}
+
+		spec := AssetSpec{
+			Value:    strings.ToLower(u.Hostname()),
+			Location: s,
+		}
+
+		number := defaultPorts[strings.ToLower(u.Scheme)]
+
+		if u.Port() != "" {
+			number, err = strconv.Atoi(u.Port())
+			if err != nil {
+				return AssetSpec{}, fmt.Errorf("bad port in %q: %w", s, err)
+			}
+		}
+
+		if number != 0 {
+			spec.Port = &AssetPort{
+				Number:   number,
+				Protocol: "tcp",
+				Service:  strings.ToLower(u.Scheme),
+			}
+		}
+
+		return spec, nil
+	}
+
+	// Bare addresses, including IPv6 which is full of colons
+	if net.ParseIP(s) != nil || !strings.Contains(s, ":") {
+		return AssetSpec{Value: s}, nil
+	}
+
+	// host:port/proto/service
+	hostPort, rest, _ := strings.Cut(s, "/")
+	protocol, service, _ := strings.Cut(rest, "/")
+
+	host, port, err := net.SplitHostPort(hostPort)
+	if err != nil {
+		return AssetSpec{}, fmt.Errorf("unable to parse %q: %w", s, err)
+	}
+
+	number, err := strconv.Atoi(port)
+	if err != nil || number < 1 || number > 65535 {
+		return AssetSpec{}, fmt.Errorf("bad port in %q", s)
+	}
+
+	if protocol == "" {
+		protocol = "tcp"
+	}
+
+	return AssetSpec{
+		Value: host,
+		Port: &AssetPort{
+			Number:   number,
+			Protocol: strings.ToLower(protocol),
+			Service:  strings.ToLower(service),
+		},
+	}, nil
+}
+
+// assetFromMap parses an entry of a finding's affected_assets.
+func assetFromMap(id string, m map[string]any) (Asset, []error) {
+	var warnings []error
+
+	asset := Asset{
+		ID: id,
+	}
+
+	if v, ok := m["asset"].(string); ok {
+		asset.Value = v
+	} else {
+		warnings = append(warnings, fmt.Errorf("unable to coerce asset into string: %#v", m["asset"]))
+	}
+
+	if v, ok := m["status"].(string); ok {
+		asset.Status = v
+	}
+
+	if v, ok := m["notes"].(string); ok {
+		asset.Notes = v
+	}
+
+	if v, ok := m["locationUrl"].(string); ok && v != "" {
+		asset.Locations = strings.Split(v, "\n")
+	}
+
+	if ports, ok := m["ports"].(map[string]any); ok {
+		for k, p := range ports {
+			portMap, ok := p.(map[string]any)
+			if !ok {
+				warnings = append(warnings, fmt.Errorf("unable to coerce port %s into map[string]interface{}", k))
+
+				continue
+			}
+
+			number, err := strconv.Atoi(fmt.Sprint(portMap["number"]))
+			if err != nil {
+				warnings = append(warnings, fmt.Errorf("unable to parse port number %#v: %w", portMap["number"], err))
+
+				continue
+			}
+
+			port := AssetPort{Number: number}
+			port.Protocol, _ = portMap["protocol"].(string)
+			port.Service, _ = portMap["service"].(string)
+
+			asset.Ports = append(asset.Ports, port)
+		}
+
+		slices.SortFunc(asset.Ports, func(a, b AssetPort) int {
+			return a.Number - b.Number
+		})
+	}
+
+	return asset, warnings
+}
+
+// mergeAssetSpec adds the ports and locations of a spec to an entry of a
+// finding's affected_assets, returning whether anything changed.
+func mergeAssetSpec(m map[string]any, spec AssetSpec) bool {
+	changed := false
+
+	if spec.Port != nil {
+		ports, ok := m["ports"].(map[string]any)
+		if !ok {
+			ports = make(map[string]any)
+			m["ports"] = ports
+		}
+
+		key := strconv.Itoa(spec.Port.Number)
+		if _, ok := ports[key]; !ok {
+			ports[key] = map[string]any{
+				"number":   key,
+				"protocol": spec.Port.Protocol,
+				"service":  spec.Port.Service,
+				"version":  "",
+			}
+			changed = true
+		}
+	}
+
+	if spec.Location != "" {
+		location, _ := m["locationUrl"].(string)
+
+		var locations []string
+		if location != "" {
+			locations = strings.Split(location, "\n")
+		}
+
+		if !slices.Contains(locations, spec.Location) {
+			m["locationUrl"] = strings.Join(append(locations, spec.Location), "\n")
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+// parseAssetSpecs parses asset specs and returns them along with the unique
+// asset values they refer to.
+func parseAssetSpecs(values []string) ([]AssetSpec, []string, error) {
+	var specs []AssetSpec
+
+	var unique []string
+
+	for _, v := range values {
+		spec, err := ParseAssetSpec(v)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		specs = append(specs, spec)
+
+		if !slices.Contains(unique, spec.Value) {
+			unique = append(unique, spec.Value)
+		}
+	}
+
+	return specs, unique, nil
 }
 
 func (f *Finding) Assets() ([]Asset, []error, error) {
@@ -24,10 +282,10 @@ func (f *Finding) Assets() ([]Asset, []error, error) {
 	return f.assets, warnings, nil
 }
 
-func (f *Finding) AddAssetBulk(value []string) error {
-	type assetStruct struct {
-		Asset string `json:"asset"`
-	}
+// compareAssets asks PlexTrac which of the values are already client level
+// assets, returning the existing assets, and documents for the new ones.
+func (c *Client) compareAssets(values []string) ([]map[string]any, []map[string]any, []error, error) {
+	var warnings []error
 
 	var compareRequest struct {
 		PastedAssets []string `json:"pastedAssets"`
@@ -35,41 +293,312 @@ func (f *Finding) AddAssetBulk(value []string) error {
 	}
 
 	var compareResponse struct {
-		Status         string        `json:"status"`
-		ExistingAssets []any         `json:"existingAssets"`
-		NewAssets      []assetStruct `json:"newAssets"`
+		Status         string           `json:"status"`
+		ExistingAssets []map[string]any `json:"existingAssets"`
+		NewAssets      []map[string]any `json:"newAssets"`
 	}
-	/*
-		var bulkRequest struct {
-			Assets []assetStruct `json:"assets"`
+
+	compareRequest.PastedAssets = values
+
+	path := fmt.Sprintf("v2/client/%d/assets/compare", c.ID)
+
+	body, err := c.ua.apiCall(http.MethodPost, path, compareRequest, &compareResponse)
+	if err != nil {
+		return nil, nil, warnings, fmt.Errorf("error comparing assets: %w: %s", err, body)
+	}
+
+	for _, a := range compareResponse.ExistingAssets {
+		if _, ok := a["id"].(string); !ok {
+			return nil, nil, warnings, fmt.Errorf("unable to coerce asset id into string: %#v", a["id"])
 		}
-	*/
+	}
 
-	// compare what's already there
-	compareRequest.PastedAssets = value
+	return compareResponse.ExistingAssets, compareResponse.NewAssets, warnings, nil
+}
+
+// createAssets creates client level assets in bulk, returning them with
+// their ids.
+func (c *Client) createAssets(docs []map[string]any) ([]map[string]any, error) {
+	var bulkResponse struct {
+		Status string           `json:"status"`
+		Assets []map[string]any `json:"assets"`
+	}
 
-	path := fmt.Sprintf("v2/client/%d/assets/compare", f.r.c.ID)
+	path := fmt.Sprintf("v2/client/%d/bulk/assets", c.ID)
 
-	body, err := f.r.ua.apiCall(http.MethodPost, path, compareRequest, &compareResponse)
+	body, err := c.ua.apiCall(http.MethodPost, path, map[string]any{
+		"assets": docs,
+	}, &bulkResponse)
 	if err != nil {
-		fmt.Printf("body: %s\n", body)
+		return nil, fmt.Errorf("error creating assets: %w: %s", err, body)
+	}
 
-		return fmt.Errorf("error comparing assets: %w", err)
+	if bulkResponse.Status != "success" {
+		return nil, fmt.Errorf("error creating assets: %s", body)
 	}
 
-	fmt.Printf("body: %#v\n", compareResponse.NewAssets)
-	// TODO collect asset ids for updating the finding
+	for _, a := range bulkResponse.Assets {
+		if _, ok := a["id"].(string); !ok {
+			return nil, fmt.Errorf("unable to coerce asset id into string: %#v", a["id"])
+		}
+	}
 
-	// TODO post the difference to add new assets
-	path = fmt.Sprintf("v2/client/%d/bulk/assets", f.r.c.ID)
+	return bulkResponse.Assets, nil
+}
+
+// resolveAssets looks up the client level assets for the given values,
+// creating any that don't exist yet. The returned documents are what
+// PlexTrac expects in a finding's affected_assets.
+func (c *Client) resolveAssets(values []string) ([]map[string]any, []error, error) {
+	assets, newAssets, warnings, err := c.compareAssets(values)
+	if err != nil {
+		return nil, warnings, err
+	}
+
+	// create the ones the client doesn't have yet
+	if len(newAssets) > 0 {
+		created, err := c.createAssets(newAssets)
+		if err != nil {
+			return nil, warnings, err
+		}
+
+		assets = append(assets, created...)
+	}
+
+	return assets, warnings, nil
+}
+
+// AddAssetBulk adds assets to the finding, creating them at the client level
+// when needed. Values are parsed with ParseAssetSpec, so ports and locations
+// are added to assets already on the finding.
+func (f *Finding) AddAssetBulk(values []string) (AssetChanges, []error, error) {
+	var changes AssetChanges
+
+	warnings, err := f.EnsureFull()
+	if err != nil {
+		return changes, warnings, err
+	}
+
+	specs, unique, err := parseAssetSpecs(values)
+	if err != nil {
+		return changes, warnings, err
+	}
+
+	resolved, warnings2, err := f.r.c.resolveAssets(unique)
+	warnings = append(warnings, warnings2...)
+
+	if err != nil {
+		return changes, warnings, err
+	}
+
+	affectedAssets, ok := f.raw["affected_assets"].(map[string]any)
+	if !ok {
+		return changes, warnings, errors.New("unable to coerce affected_assets into map[string]interface{}")
+	}
+
+	for _, doc := range resolved {
+		id, _ := doc["id"].(string)
+		value, _ := doc["asset"].(string)
+
+		// The finding might know the asset by a different id
+		for _, a := range f.assets {
+			if a.Value == value {
+				id = a.ID
+			}
+		}
+
+		entry, existing := affectedAssets[id].(map[string]any)
+		if !existing {
+			entry = doc
+		}
+
+		updated := false
+
+		for _, spec := range specs {
+			if spec.Value == value && mergeAssetSpec(entry, spec) {
+				updated = true
+			}
+		}
+
+		affectedAssets[id] = entry
+		asset, assetWarnings := assetFromMap(id, entry)
+		warnings = append(warnings, assetWarnings...)
+
+		switch {
+		case !existing:
+			changes.Added = append(changes.Added, asset)
+		case updated:
+			changes.Updated = append(changes.Updated, asset)
+		default:
+			changes.Unchanged = append(changes.Unchanged, asset)
+		}
+	}
+
+	if len(changes.Added) == 0 && len(changes.Updated) == 0 {
+		return changes, warnings, nil
+	}
+
+	f.assets, warnings2, err = findingAssets(f.raw)
+	warnings = append(warnings, warnings2...)
+
+	if err != nil {
+		return changes, warnings, err
+	}
+
+	warnings2, err = f.update()
+	warnings = append(warnings, warnings2...)
+
+	return changes, warnings, err
+}
+
+// MatchAsset reports whether an asset value matches a pattern. Patterns can
+// be an exact value, a glob like *.corp.local, or a CIDR like 10.0.0.0/24.
+// Matching is case-insensitive.
+func MatchAsset(value string, pattern string) bool {
+	if strings.EqualFold(value, pattern) {
+		return true
+	}
+
+	if _, network, err := net.ParseCIDR(pattern); err == nil {
+		ip := net.ParseIP(value)
+
+		return ip != nil && network.Contains(ip)
+	}
+
+	ok, err := path.Match(strings.ToLower(pattern), strings.ToLower(value))
+
+	return err == nil && ok
+}
+
+// RemoveAssets removes all affected assets matching any of the patterns from
+// the finding. See MatchAsset for the pattern syntax.
+func (f *Finding) RemoveAssets(patterns []string) (AssetChanges, []error, error) {
+	var changes AssetChanges
+
+	warnings, err := f.EnsureFull()
+	if err != nil {
+		return changes, warnings, err
+	}
+
+	affectedAssets, ok := f.raw["affected_assets"].(map[string]any)
+	if !ok {
+		return changes, warnings, errors.New("unable to coerce affected_assets into map[string]interface{}")
+	}
+
+	f.assets = slices.DeleteFunc(f.assets, func(a Asset) bool {
+		for _, p := range patterns {
+			if MatchAsset(a.Value, p) {
+				delete(affectedAssets, a.ID)
+				changes.Removed = append(changes.Removed, a)
+
+				return true
+			}
+		}
+
+		changes.Unchanged = append(changes.Unchanged, a)
+
+		return false
+	})
+
+	if len(changes.Removed) == 0 {
+		return changes, warnings, nil
+	}
+
+	warnings2, err := f.update()
+	warnings = append(warnings, warnings2...)
+
+	return changes, warnings, err
+}
+
+// SetAssets replaces the affected assets of the finding with the given
+// values, creating client level assets as needed. Values are parsed with
+// ParseAssetSpec.
+func (f *Finding) SetAssets(values []string) (AssetChanges, []error, error) {
+	var changes AssetChanges
+
+	warnings, err := f.EnsureFull()
+	if err != nil {
+		return changes, warnings, err
+	}
+
+	specs, unique, err := parseAssetSpecs(values)
+	if err != nil {
+		return changes, warnings, err
+	}
+
+	resolved, warnings2, err := f.r.c.resolveAssets(unique)
+	warnings = append(warnings, warnings2...)
 
-	body, err = f.r.ua.apiCall(http.MethodPost, path, compareRequest, &compareResponse)
 	if err != nil {
-		fmt.Printf("body: %s\n", body)
+		return changes, warnings, err
+	}
+
+	oldAssets, ok := f.raw["affected_assets"].(map[string]any)
+	if !ok {
+		return changes, warnings, errors.New("unable to coerce affected_assets into map[string]interface{}")
+	}
+
+	affectedAssets := make(map[string]any)
+
+	for _, doc := range resolved {
+		id, _ := doc["id"].(string)
+		value, _ := doc["asset"].(string)
+
+		for _, a := range f.assets {
+			if a.Value == value {
+				id = a.ID
+			}
+		}
+
+		// Keep what's already on the finding so per finding details stay
+		entry, existing := oldAssets[id].(map[string]any)
+		if !existing {
+			entry = doc
+		}
+
+		updated := false
+
+		for _, spec := range specs {
+			if spec.Value == value && mergeAssetSpec(entry, spec) {
+				updated = true
+			}
+		}
 
-		return fmt.Errorf("error comparing assets: %w", err)
+		affectedAssets[id] = entry
+		asset, assetWarnings := assetFromMap(id, entry)
+		warnings = append(warnings, assetWarnings...)
+
+		switch {
+		case !existing:
+			changes.Added = append(changes.Added, asset)
+		case updated:
+			changes.Updated = append(changes.Updated, asset)
+		default:
+			changes.Unchanged = append(changes.Unchanged, asset)
+		}
+	}
+
+	for _, a := range f.assets {
+		if _, ok := affectedAssets[a.ID]; !ok {
+			changes.Removed = append(changes.Removed, a)
+		}
+	}
+
+	if len(changes.Added) == 0 && len(changes.Updated) == 0 && len(changes.Removed) == 0 {
+		return changes, warnings, nil
 	}
-	// TODO collect asset ids for updating the finding
 
-	return errors.New("not implemented yet")
+	f.raw["affected_assets"] = affectedAssets
+
+	f.assets, warnings2, err = findingAssets(f.raw)
+	warnings = append(warnings, warnings2...)
+
+	if err != nil {
+		return changes, warnings, err
+	}
+
+	warnings2, err = f.update()
+	warnings = append(warnings, warnings2...)
+
+	return changes, warnings, err
 }
@@ -0,0 +1,401 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package plextrac_test
+
+import (
+	"testing"
+
+	"github.com/brimstone/plextraccli/plextrac"
+)
+
+// assetRoutes serves a finding with one affected asset, and a client asset
+// inventory where dc01 already exists and anything else gets created.
+func assetRoutes() map[string]mockRoute {
+	raw := testFindingRaw()
+	raw["affected_assets"] = map[string]any{
+		"asset-dc01": map[string]any{"id": "asset-dc01", "asset": "dc01.corp.local"},
+	}
+
+	routes := findingRoutes(raw)
+
+	routes["POST /api/v2/client/123/assets/compare"] = func(t *testing.T, body map[string]any) any {
+		t.Helper()
+
+		pasted, _ := body["pastedAssets"].([]any)
+
+		var existing, created []map[string]any
+
+		for _, p := range pasted {
+			value, _ := p.(string)
+			if value == "dc01.corp.local" || value == "ws01.corp.local" {
+				existing = append(existing, map[string]any{"id": "asset-" + value[:4], "asset": value})
+			} else {
+				created = append(created, map[string]any{"asset": value})
+			}
+		}
+
+		return map[string]any{
+			"status":         "success",
+			"existingAssets": existing,
+			"newAssets":      created,
+		}
+	}
+	routes["POST /api/v2/client/123/bulk/assets"] = func(t *testing.T, body map[string]any) any {
+		t.Helper()
+
+		requested, _ := body["assets"].([]any)
+
+		var assets []map[string]any
+
+		for _, r := range requested {
+			a, _ := r.(map[string]any)
+			value, _ := a["asset"].(string)
+			assets = append(assets, map[string]any{"id": "asset-new-" + value, "asset": value})
+		}
+
+		return map[string]any{"status": "success", "assets": assets}
+	}
+
+	return routes
+}
+
+func TestFinding_AddAssetBulk(t *testing.T) {
+	t.Parallel()
+
+	m := newMockAPI(assetRoutes())
+	f := mockFinding(t, m)
+
+	changes, _, err := f.AddAssetBulk([]string{"dc01.corp.local", "ws01.corp.local", "10.0.0.5", "10.0.0.5"})
+	if err != nil {
+		t.Fatalf("AddAssetBulk() returned error: %v", err)
+	}
+
+	if len(changes.Added) != 2 {
+		t.Errorf("expected 2 added assets, got %#v", changes.Added)
+	}
+
+	if len(changes.Unchanged) != 1 || changes.Unchanged[0].Value != "dc01.corp.local" {
+		t.Errorf("expected dc01 to already be present, got %#v", changes.Unchanged)
+	}
+
+	creates := m.requestsFor("POST /api/v2/client/123/bulk/assets")
+	if len(creates) != 1 {
+		t.Fatalf("expected one bulk create, got %d", len(creates))
+	}
+
+	if requested, _ := creates[0]["assets"].([]any); len(requested) != 1 {
+		t.Errorf("expected only the new asset to be created, got %#v", creates[0]["assets"])
+	}
+
+	puts := m.requestsFor("PUT /api/v1/client/123/report/456/flaw/789")
+	if len(puts) != 1 {
+		t.Fatalf("expected one update, got %d", len(puts))
+	}
+
+	affected, _ := puts[0]["affected_assets"].(map[string]any)
+	for _, id := range []string{"asset-dc01", "asset-ws01", "asset-new-10.0.0.5"} {
+		if _, ok := affected[id]; !ok {
+			t.Errorf("expected %s in affected_assets, got %#v", id, affected)
+		}
+	}
+
+	assets, _, err := f.Assets()
+	if err != nil {
+		t.Fatalf("Assets() returned error: %v", err)
+	}
+
+	if len(assets) != 3 {
+		t.Errorf("expected 3 assets on the finding, got %#v", assets)
+	}
+}
+
+func TestFinding_AddAssetBulk_nothing_new(t *testing.T) {
+	t.Parallel()
+
+	m := newMockAPI(assetRoutes())
+	f := mockFinding(t, m)
+
+	changes, _, err := f.AddAssetBulk([]string{"dc01.corp.local"})
+	if err != nil {
+		t.Fatalf("AddAssetBulk() returned error: %v", err)
+	}
+
+	if len(changes.Added) != 0 || len(changes.Unchanged) != 1 {
+		t.Errorf("expected nothing to be added, got %#v", changes)
+	}
+
+	if len(m.requestsFor("POST /api/v2/client/123/bulk/assets")) != 0 {
+		t.Error("expected no assets to be created")
+	}
+
+	if len(m.requestsFor("PUT /api/v1/client/123/report/456/flaw/789")) != 0 {
+		t.Error("expected the finding not to be updated")
+	}
+}
+
+func TestMatchAsset(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value   string
+		pattern string
+		match   bool
+	}{
+		{"dc01.corp.local", "dc01.corp.local", true},
+		{"DC01.corp.local", "dc01.CORP.local", true},
+		{"dc01.corp.local", "*.corp.local", true},
+		{"dc01.corp.local", "ws*", false},
+		{"10.0.0.5", "10.0.0.0/24", true},
+		{"10.0.1.5", "10.0.0.0/24", false},
+		{"dc01.corp.local", "10.0.0.0/24", false},
+		{"10.0.0.5", "10.0.0.5", true},
+		{"10.0.0.5", "10.0.0.50", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value+" "+tt.pattern, func(t *testing.T) {
+			t.Parallel()
+
+			got := plextrac.MatchAsset(tt.value, tt.pattern)
+			if got != tt.match {
+				t.Errorf("MatchAsset(%q, %q) = %v, want %v", tt.value, tt.pattern, got, tt.match)
+			}
+		})
+	}
+}
+
+func TestFinding_RemoveAssets(t *testing.T) {
+	t.Parallel()
+
+	raw := testFindingRaw()
+	raw["affected_assets"] = map[string]any{
+		"a1": map[string]any{"id": "a1", "asset": "10.0.0.5"},
+		"a2": map[string]any{"id": "a2", "asset": "10.0.1.5"},
+		"a3": map[string]any{"id": "a3", "asset": "dc01.corp.local"},
+	}
+
+	m := newMockAPI(findingRoutes(raw))
+	f := mockFinding(t, m)
+
+	changes, _, err := f.RemoveAssets([]string{"10.0.0.0/24", "*.corp.local"})
+	if err != nil {
+		t.Fatalf("RemoveAssets() returned error: %v", err)
+	}
+
+	if len(changes.Removed) != 2 || len(changes.Unchanged) != 1 {
+		t.Fatalf("expected 2 removed and 1 left, got %#v", changes)
+	}
+
+	puts := m.requestsFor("PUT /api/v1/client/123/report/456/flaw/789")
+	if len(puts) != 1 {
+		t.Fatalf("expected one update, got %d", len(puts))
+	}
+
+	affected, _ := puts[0]["affected_assets"].(map[string]any)
+	if len(affected) != 1 || affected["a2"] == nil {
+		t.Errorf("expected only a2 to be left, got %#v", affected)
+	}
+}
+
+func TestFinding_SetAssets(t *testing.T) {
+	t.Parallel()
+
+	m := newMockAPI(assetRoutes())
+	f := mockFinding(t, m)
+
+	changes, _, err := f.SetAssets([]string{"ws01.corp.local", "10.0.0.9"})
+	if err != nil {
+		t.Fatalf("SetAssets() returned error: %v", err)
+	}
+
+	if len(changes.Added) != 2 || len(changes.Removed) != 1 || changes.Removed[0].Value != "dc01.corp.local" {
+		t.Fatalf("expected 2 added and dc01 removed, got %#v", changes)
+	}
+
+	puts := m.requestsFor("PUT /api/v1/client/123/report/456/flaw/789")
+	if len(puts) != 1 {
+		t.Fatalf("expected one update, got %d", len(puts))
+	}
+
+	affected, _ := puts[0]["affected_assets"].(map[string]any)
+	if len(affected) != 2 || affected["asset-dc01"] != nil {
+		t.Errorf("expected only the new assets, got %#v", affected)
+	}
+}
+
+func TestParseAssetSpec(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in       string
+		expected plextrac.AssetSpec
+		err      bool
+	}{
+		{in: "dc01.corp.local", expected: plextrac.AssetSpec{Value: "dc01.corp.local"}},
+		{in: "10.0.0.5", expected: plextrac.AssetSpec{Value: "10.0.0.5"}},
+		{in: "fe80::1", expected: plextrac.AssetSpec{Value: "fe80::1"}},
+		{
+			in: "10.0.0.5:445",
+			expected: plextrac.AssetSpec{
+				Value: "10.0.0.5",
+				Port:  &plextrac.AssetPort{Number: 445, Protocol: "tcp"},
+			},
+		},
+		{
+			in: "10.0.0.5:161/UDP",
+			expected: plextrac.AssetSpec{
+				Value: "10.0.0.5",
+				Port:  &plextrac.AssetPort{Number: 161, Protocol: "udp"},
+			},
+		},
+		{
+			in: "10.0.0.5:445/tcp/Microsoft-DS",
+			expected: plextrac.AssetSpec{
+				Value: "10.0.0.5",
+				Port:  &plextrac.AssetPort{Number: 445, Protocol: "tcp", Service: "microsoft-ds"},
+			},
+		},
+		{
+			in: "[fe80::1]:22",
+			expected: plextrac.AssetSpec{
+				Value: "fe80::1",
+				Port:  &plextrac.AssetPort{Number: 22, Protocol: "tcp"},
+			},
+		},
+		{
+			in: "https://WWW.example.com/login",
+			expected: plextrac.AssetSpec{
+				Value:    "www.example.com",
+				Port:     &plextrac.AssetPort{Number: 443, Protocol: "tcp", Service: "https"},
+				Location: "https://WWW.example.com/login",
+			},
+		},
+		{
+			in: "http://www.example.com:8080/",
+			expected: plextrac.AssetSpec{
+				Value:    "www.example.com",
+				Port:     &plextrac.AssetPort{Number: 8080, Protocol: "tcp", Service: "http"},
+				Location: "http://www.example.com:8080/",
+			},
+		},
+		{in: "", err: true},
+		{in: "10.0.0.5:http", err: true},
+		{in: "10.0.0.5:70000", err: true},
+		{in: "file:///etc/passwd", err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := plextrac.ParseAssetSpec(tt.in)
+			if tt.err {
+				if err == nil {
+					t.Errorf("ParseAssetSpec(%q) expected an error, got %#v", tt.in, got)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ParseAssetSpec(%q) returned error: %v", tt.in, err)
+			}
+
+			if got.Value != tt.expected.Value || got.Location != tt.expected.Location {
+				t.Errorf("ParseAssetSpec(%q) = %#v, want %#v", tt.in, got, tt.expected)
+			}
+
+			if (got.Port == nil) != (tt.expected.Port == nil) ||
+				(got.Port != nil && *got.Port != *tt.expected.Port) {
+				t.Errorf("ParseAssetSpec(%q) port = %#v, want %#v", tt.in, got.Port, tt.expected.Port)
+			}
+		})
+	}
+}
+
+func TestFinding_Assets_details(t *testing.T) {
+	t.Parallel()
+
+	raw := testFindingRaw()
+	raw["affected_assets"] = map[string]any{
+		"a1": map[string]any{
+			"id":          "a1",
+			"asset":       "www.example.com",
+			"status":      "Open",
+			"notes":       "seen during day two",
+			"locationUrl": "https://www.example.com/login\nhttps://www.example.com/admin",
+			"ports": map[string]any{
+				"443": map[string]any{"number": "443", "protocol": "tcp", "service": "https"},
+				"80":  map[string]any{"number": "80", "protocol": "tcp", "service": "http"},
+			},
+		},
+	}
+
+	m := newMockAPI(findingRoutes(raw))
+	f := mockFinding(t, m)
+
+	assets, _, err := f.Assets()
+	if err != nil {
+		t.Fatalf("Assets() returned error: %v", err)
+	}
+
+	if len(assets) != 1 {
+		t.Fatalf("expected one asset, got %#v", assets)
+	}
+
+	a := assets[0]
+	if a.Status != "Open" || a.Notes != "seen during day two" {
+		t.Errorf("unexpected status or notes: %#v", a)
+	}
+
+	if len(a.Locations) != 2 || a.Locations[1] != "https://www.example.com/admin" {
+		t.Errorf("unexpected locations: %#v", a.Locations)
+	}
+
+	if len(a.Ports) != 2 || a.Ports[0].String() != "80/tcp (http)" || a.Ports[1].Number != 443 {
+		t.Errorf("unexpected ports: %#v", a.Ports)
+	}
+}
+
+func TestFinding_AddAssetBulk_details(t *testing.T) {
+	t.Parallel()
+
+	m := newMockAPI(assetRoutes())
+	f := mockFinding(t, m)
+
+	changes, _, err := f.AddAssetBulk([]string{"dc01.corp.local:445", "https://www.example.com/login"})
+	if err != nil {
+		t.Fatalf("AddAssetBulk() returned error: %v", err)
+	}
+
+	if len(changes.Added) != 1 || len(changes.Updated) != 1 || changes.Updated[0].Value != "dc01.corp.local" {
+		t.Fatalf("expected www added and dc01 updated, got %#v", changes)
+	}
+
+	puts := m.requestsFor("PUT /api/v1/client/123/report/456/flaw/789")
+	if len(puts) != 1 {
+		t.Fatalf("expected one update, got %d", len(puts))
+	}
+
+	affected, _ := puts[0]["affected_assets"].(map[string]any)
+
+	dc01, _ := affected["asset-dc01"].(map[string]any)
+	if ports, _ := dc01["ports"].(map[string]any); ports["445"] == nil {
+		t.Errorf("expected port 445 on dc01, got %#v", dc01)
+	}
+
+	www, _ := affected["asset-new-www.example.com"].(map[string]any)
+	if www["locationUrl"] != "https://www.example.com/login" {
+		t.Errorf("expected the location on www, got %#v", www)
+	}
+
+	// Adding the same details again changes nothing
+	changes, _, err = f.AddAssetBulk([]string{"dc01.corp.local:445"})
+	if err != nil {
+		t.Fatalf("AddAssetBulk() returned error: %v", err)
+	}
+
+	if len(changes.Unchanged) != 1 || len(m.requestsFor("PUT /api/v1/client/123/report/456/flaw/789")) != 1 {
+		t.Errorf("expected no further update, got %#v", changes)
+	}
+}
@@ -230,10 +230,8 @@ func (c *Client) SetDescription(description string) ([]error, error) {
 func (c *Client) update() ([]error, error) {
 	path := fmt.Sprintf("v1/client/%d", c.ID)
 
-	body, err := c.ua.apiCall(http.MethodPut, path, c.raw, nil)
+	_, err := c.ua.apiCall(http.MethodPut, path, c.raw, nil)
 	if err != nil {
-		fmt.Printf("body: %s\n", body)
-
 		return nil, fmt.Errorf("error updating client: %w", err)
 	}
 
@@ -3,6 +3,7 @@
 package plextrac
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -16,13 +17,44 @@ type Finding struct {
 	full   bool
 	raw    map[string]any
 
-	ID        int
-	Status    string
-	Name      string
-	Published bool
-	Evidence  string
-	Severity  string
-	tags      []string
+	ID              int
+	Status          string
+	Name            string
+	Published       bool
+	Description     string
+	Recommendations string
+	References      string
+	Evidence        string
+	Severity        string
+	tags            []string
+}
+
+// Severities are the severities PlexTrac accepts for a finding, in order.
+var Severities = []string{"Critical", "High", "Medium", "Low", "Informational"}
+
+// Statuses are the statuses PlexTrac accepts for a finding.
+var Statuses = []string{"Open", "In Process", "Closed"}
+
+// checkSeverity returns the severity as PlexTrac spells it, or an error if
+// it isn't one of Severities.
+func checkSeverity(severity string) (string, error) {
+	i := slices.IndexFunc(Severities, func(s string) bool { return strings.EqualFold(s, severity) })
+	if i == -1 {
+		return "", fmt.Errorf("unknown severity %q, must be one of: %s", severity, strings.Join(Severities, ", "))
+	}
+
+	return Severities[i], nil
+}
+
+// checkStatus returns the status as PlexTrac spells it, or an error if it
+// isn't one of Statuses.
+func checkStatus(status string) (string, error) {
+	i := slices.IndexFunc(Statuses, func(s string) bool { return strings.EqualFold(s, status) })
+	if i == -1 {
+		return "", fmt.Errorf("unknown status %q, must be one of: %s", status, strings.Join(Statuses, ", "))
+	}
+
+	return Statuses[i], nil
 }
 
 type findingsResponse struct {
@@ -151,19 +183,15 @@ func findingAssets(m map[string]any) ([]Asset, []error, error) {
 			return assets, warnings, errors.New("unable to coerce asset into map[string]interface{}")
 		}
 
-		asset := Asset{
-			ID: k,
-		}
-
-		if v, ok := asset_map["asset"].(string); ok {
-			asset.Value = v
-		} else {
-			warnings = append(warnings, fmt.Errorf("unable to coerce asset into string: %#v", asset_map["asset"]))
-		}
-		// fmt.Printf("Asset %s: %#v\n", k, asset_map["asset"])
+		asset, assetWarnings := assetFromMap(k, asset_map)
+		warnings = append(warnings, assetWarnings...)
 		assets = append(assets, asset)
 	}
 
+	slices.SortFunc(assets, func(a, b Asset) int {
+		return strings.Compare(a.Value, b.Value)
+	})
+
 	return assets, warnings, nil
 }
 
@@ -192,26 +220,56 @@ func findingEvidence(m map[string]any) (string, []error, error) {
 	return value, nil, nil
 }
 
-func (f *Finding) EnsureFull() ([]error, error) {
-	var warnings []error
+// NewFinding is a finding from its document, as PlexTrac has it or the
+// importer makes it, that isn't in a report. The document goes through JSON
+// first, as it would on its way to PlexTrac.
+func NewFinding(doc map[string]any) (*Finding, []error, error) {
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	var warningsParsed []error
+	f := &Finding{full: true}
 
-	var err error
+	err = json.Unmarshal(data, &f.raw)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	f.Name, _ = f.raw["title"].(string)
+	f.Severity, _ = f.raw["severity"].(string)
+	f.Status, _ = f.raw["status"].(string)
 
+	warnings, err := f.parse()
+
+	return f, warnings, err
+}
+
+func (f *Finding) EnsureFull() ([]error, error) {
 	if f.full {
 		return nil, nil
 	}
 
 	path := fmt.Sprintf("v1/client/%d/report/%d/flaw/%d", f.r.c.ID, f.r.ID, f.ID)
 
-	_, err = f.r.ua.apiGet(path, &f.raw)
+	_, err := f.r.ua.apiGet(path, &f.raw)
 	if err != nil {
 		return nil, err
 	}
 
 	f.full = true
 
+	return f.parse()
+}
+
+// parse fills in the fields of the finding from its document.
+func (f *Finding) parse() ([]error, error) {
+	var warnings []error
+
+	var warningsParsed []error
+
+	var err error
+
 	// Parse Affected Assets
 	f.assets, warningsParsed, err = findingAssets(f.raw)
 	if err != nil {
@@ -228,6 +286,23 @@ func (f *Finding) EnsureFull() ([]error, error) {
 
 	warnings = append(warnings, warningsParsed...)
 
+	// Parse rich text fields
+	for k, v := range map[string]*string{
+		"description":     &f.Description,
+		"recommendations": &f.Recommendations,
+		"references":      &f.References,
+	} {
+		if f.raw[k] == nil {
+			continue
+		}
+
+		if s, ok := f.raw[k].(string); ok {
+			*v = s
+		} else {
+			warnings = append(warnings, fmt.Errorf("unable to coerce %s %#v into a string", k, f.raw[k]))
+		}
+	}
+
 	// Parse Tags
 	if tags, ok := f.raw["tags"].([]any); ok {
 		for _, t := range tags {
@@ -244,6 +319,54 @@ func (f *Finding) EnsureFull() ([]error, error) {
 	return warnings, nil
 }
 
+// Field returns the value of a custom field of the finding, or an empty
+// string if it doesn't have it.
+func (f *Finding) Field(key string) string {
+	_, _ = f.EnsureFull()
+
+	fields, _ := f.raw["fields"].(map[string]any)
+	field, _ := fields[key].(map[string]any)
+	value, _ := field["value"].(string)
+
+	return value
+}
+
+// CVSS3 returns the CVSS v3 vector of the finding, from the vector of its
+// risk score, or the scores copied from a writeup, or an empty string if it
+// doesn't have one.
+func (f *Finding) CVSS3() string {
+	_, _ = f.EnsureFull()
+
+	riskScore, _ := f.raw["risk_score"].(map[string]any)
+	cvss3, _ := riskScore["CVSS3"].(map[string]any)
+
+	if vector, _ := cvss3["vector"].(string); vector != "" {
+		return vector
+	}
+
+	fields, _ := f.raw["fields"].(map[string]any)
+	scores, _ := fields["scores"].(map[string]any)
+	cvss3, _ = scores["cvss3"].(map[string]any)
+	vector, _ := cvss3["calculation"].(string)
+
+	return vector
+}
+
+// WriteupID returns the ID of the writeup the finding was created from, or
+// an empty string if it wasn't.
+func (f *Finding) WriteupID() string {
+	_, _ = f.EnsureFull()
+
+	id, _ := f.raw["writeupID"].(string)
+
+	return id
+}
+
+// Report is the report the finding is in.
+func (f *Finding) Report() *Report {
+	return f.r
+}
+
 func (f *Finding) Tags() []string {
 	_, _ = f.EnsureFull()
 
@@ -273,7 +396,6 @@ func (f *Finding) RemoveTags(tags []string) ([]error, error) {
 	f.tags = slices.DeleteFunc(f.tags, func(t string) bool {
 		return slices.Contains(tags, t)
 	})
-	fmt.Printf("tags: %#v\n", f.tags)
 	f.raw["tags"] = f.tags
 	warnings2, err := f.update()
 	warnings = append(warnings, warnings2...)
@@ -294,13 +416,172 @@ func (f *Finding) SetTags(tags []string) ([]error, error) {
 
 	return warnings, err
 }
+
+// FindingUpdate is the fields to change on a finding. Nil fields are left
+// alone.
+type FindingUpdate struct {
+	Title           *string
+	Severity        *string
+	Status          *string
+	Published       *bool
+	Description     *string
+	Recommendations *string
+	References      *string
+	Evidence        *string
+}
+
+// Update changes the fields of the finding in one request. The fields are
+// checked before any are changed, so a bad severity doesn't leave the
+// finding half updated.
+func (f *Finding) Update(u FindingUpdate) ([]error, error) {
+	if u.Title != nil && *u.Title == "" {
+		return nil, errors.New("title can't be empty")
+	}
+
+	var severity, status string
+
+	if u.Severity != nil {
+		var err error
+
+		severity, err = checkSeverity(*u.Severity)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if u.Status != nil {
+		var err error
+
+		status, err = checkStatus(*u.Status)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	warnings, err := f.EnsureFull()
+	if err != nil {
+		return warnings, err
+	}
+
+	var evidence map[string]any
+
+	if u.Evidence != nil {
+		fields, ok := f.raw["fields"].(map[string]any)
+		if !ok {
+			return warnings, errors.New("unable to coerce fields into map[string]interface{}")
+		}
+
+		evidence, ok = fields["evidence"].(map[string]any)
+		if !ok {
+			evidence = map[string]any{
+				"key":        "evidence",
+				"label":      "Evidence",
+				"sort_order": 0,
+			}
+			fields["evidence"] = evidence
+		}
+	}
+
+	if u.Title != nil {
+		f.Name = *u.Title
+		f.raw["title"] = f.Name
+	}
+
+	if severity != "" {
+		f.Severity = severity
+		f.raw["severity"] = f.Severity
+	}
+
+	if status != "" {
+		f.Status = status
+		f.raw["status"] = f.Status
+	}
+
+	if u.Published != nil {
+		f.Published = *u.Published
+		f.raw["visibility"] = "draft"
+
+		if f.Published {
+			f.raw["visibility"] = "published"
+		}
+	}
+
+	for _, field := range []struct {
+		key   string
+		value *string
+		v     *string
+	}{
+		{"description", u.Description, &f.Description},
+		{"recommendations", u.Recommendations, &f.Recommendations},
+		{"references", u.References, &f.References},
+	} {
+		if field.value != nil {
+			*field.v = *field.value
+			f.raw[field.key] = *field.value
+		}
+	}
+
+	if u.Evidence != nil {
+		f.Evidence = *u.Evidence
+		evidence["value"] = f.Evidence
+	}
+
+	warnings2, err := f.update()
+	warnings = append(warnings, warnings2...)
+
+	return warnings, err
+}
+
+func (f *Finding) SetTitle(title string) ([]error, error) {
+	return f.Update(FindingUpdate{Title: &title})
+}
+
+func (f *Finding) SetSeverity(severity string) ([]error, error) {
+	return f.Update(FindingUpdate{Severity: &severity})
+}
+
+func (f *Finding) SetStatus(status string) ([]error, error) {
+	return f.Update(FindingUpdate{Status: &status})
+}
+
+func (f *Finding) SetPublished(published bool) ([]error, error) {
+	return f.Update(FindingUpdate{Published: &published})
+}
+
+func (f *Finding) SetDescription(description string) ([]error, error) {
+	return f.Update(FindingUpdate{Description: &description})
+}
+
+func (f *Finding) SetRecommendations(recommendations string) ([]error, error) {
+	return f.Update(FindingUpdate{Recommendations: &recommendations})
+}
+
+func (f *Finding) SetReferences(references string) ([]error, error) {
+	return f.Update(FindingUpdate{References: &references})
+}
+
+func (f *Finding) SetEvidence(evidence string) ([]error, error) {
+	return f.Update(FindingUpdate{Evidence: &evidence})
+}
+
+// AppendEvidence adds the rich text to the end of the finding's evidence.
+func (f *Finding) AppendEvidence(evidence string) ([]error, error) {
+	warnings, err := f.EnsureFull()
+	if err != nil {
+		return warnings, err
+	}
+
+	warnings2, err := f.SetEvidence(f.Evidence + evidence)
+	warnings = append(warnings, warnings2...)
+
+	return warnings, err
+}
+
 func (f *Finding) update() ([]error, error) {
 	path := fmt.Sprintf("v1/client/%d/report/%d/flaw/%d", f.r.c.ID, f.r.ID, f.ID)
 
-	body, err := f.r.ua.apiCall(http.MethodPut, path, f.raw, nil)
+	_, err := f.r.ua.apiCall(http.MethodPut, path, f.raw, nil)
 	if err != nil {
-		fmt.Printf("body: %s\n", body)
-
 		return nil, fmt.Errorf("error updating finding: %w", err)
 	}
 
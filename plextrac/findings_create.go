@@ -0,0 +1,131 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package plextrac
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// FindingOverrides replaces values copied from a writeup when creating a
+// finding. Empty values are left alone.
+type FindingOverrides struct {
+	Title    string
+	Severity string
+	Status   string
+	Tags     []string
+}
+
+// CreateFinding creates a new finding in the report from a raw PlexTrac
+// finding document.
+func (r *Report) CreateFinding(doc map[string]any) (*Finding, []error, error) {
+	var response struct {
+		Status  string `json:"status"`
+		Message string `json:"message"`
+		FlawID  int    `json:"flaw_id"`
+	}
+
+	title, ok := doc["title"].(string)
+	if !ok || title == "" {
+		return nil, nil, errors.New("finding must have a title")
+	}
+
+	if _, ok := doc["status"]; !ok {
+		doc["status"] = "Open"
+	}
+
+	if _, ok := doc["visibility"]; !ok {
+		doc["visibility"] = "draft"
+	}
+
+	path := fmt.Sprintf("v1/client/%d/report/%d/flaw/create", r.c.ID, r.ID)
+
+	body, err := r.ua.apiCall(http.MethodPost, path, doc, &response)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating finding: %w", err)
+	}
+
+	if response.Status != "success" || response.FlawID == 0 {
+		return nil, nil, fmt.Errorf("error creating finding: %s", body)
+	}
+
+	f := &Finding{
+		r:         r,
+		ID:        response.FlawID,
+		Name:      title,
+		Published: doc["visibility"] == "published",
+	}
+
+	if s, ok := doc["severity"].(string); ok {
+		f.Severity = s
+	}
+
+	if s, ok := doc["status"].(string); ok {
+		f.Status = s
+	}
+
+	r.findings = append(r.findings, f)
+
+	return f, nil, nil
+}
+
+// AddFindingFromWriteup creates a new finding in the report from a WriteupsDB
+// entry, copying its title, severity, description, recommendations,
+// references and custom fields.
+func (r *Report) AddFindingFromWriteup(w *Writeup, o FindingOverrides) (*Finding, []error, error) {
+	var err error
+
+	if o.Severity != "" {
+		o.Severity, err = checkSeverity(o.Severity)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if o.Status != "" {
+		o.Status, err = checkStatus(o.Status)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	fields, err := w.findingFields()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	doc := map[string]any{
+		"title":           w.Title,
+		"severity":        w.Severity,
+		"description":     w.Description,
+		"recommendations": w.Recommendations,
+		"references":      w.References,
+		"fields":          fields,
+		"tags":            w.Tags,
+		"affected_assets": map[string]any{},
+		"writeupID":       w.ID,
+	}
+
+	if o.Title != "" {
+		doc["title"] = o.Title
+	}
+
+	if o.Severity != "" {
+		doc["severity"] = o.Severity
+	}
+
+	if o.Status != "" {
+		doc["status"] = o.Status
+	}
+
+	if o.Tags != nil {
+		doc["tags"] = o.Tags
+	}
+
+	if doc["tags"] == nil {
+		doc["tags"] = []string{}
+	}
+
+	return r.CreateFinding(doc)
+}
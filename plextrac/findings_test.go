@@ -0,0 +1,686 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package plextrac_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/brimstone/plextraccli/plextrac"
+)
+
+// mockRoute answers one API call, keyed by method and path, and gets the
+// decoded request body.
+type mockRoute func(t *testing.T, body map[string]any) any
+
+// mockAPI is a fake PlexTrac instance with one client and one report. Extra
+// routes are layered on top, and every request body is recorded.
+type mockAPI struct {
+	mu       sync.Mutex
+	routes   map[string]mockRoute
+	requests map[string][]map[string]any
+}
+
+func newMockAPI(routes map[string]mockRoute) *mockAPI {
+	m := &mockAPI{
+		routes: map[string]mockRoute{
+			"POST /api/v2/clients": func(t *testing.T, body map[string]any) any {
+				t.Helper()
+
+				return map[string]any{
+					"status": "success",
+					"data": []map[string]any{
+						{"client_id": 123, "name": "Test Client", "tags": []string{}},
+					},
+				}
+			},
+			"GET /api/v1/client/123/reports": func(t *testing.T, body map[string]any) any {
+				t.Helper()
+
+				return []map[string]any{
+					{
+						"id": 456,
+						"data": []any{
+							456, "Test Report", nil, "Draft", 1, []string{"op"}, []string{},
+							1700000000000, "2025-01-01T00:00:00.000Z", nil, []string{"scope_ipt"},
+							"template", "findings",
+						},
+					},
+				}
+			},
+		},
+		requests: make(map[string][]map[string]any),
+	}
+
+	for k, v := range routes {
+		m.routes[k] = v
+	}
+
+	return m
+}
+
+func (m *mockAPI) handler(t *testing.T) http.HandlerFunc {
+	t.Helper()
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		key := r.Method + " " + r.URL.Path
+
+		var body map[string]any
+
+		data, _ := io.ReadAll(r.Body)
+		if len(data) > 0 {
+			_ = json.Unmarshal(data, &body)
+		}
+
+		m.mu.Lock()
+		m.requests[key] = append(m.requests[key], body)
+		route, ok := m.routes[key]
+		m.mu.Unlock()
+
+		if !ok {
+			t.Errorf("unexpected request: %s", key)
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		resp := route(t, body)
+
+		// Raw bytes are served as is, like uploaded images
+		if data, ok := resp.([]byte); ok {
+			_, _ = w.Write(data)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		err := json.NewEncoder(w).Encode(resp)
+		if err != nil {
+			t.Errorf("failed to encode response: %v", err)
+		}
+	}
+}
+
+func (m *mockAPI) requestsFor(key string) []map[string]any {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.requests[key]
+}
+
+// mockUserAgent starts a server for the mock API and returns a user agent
+// logged in to it.
+func mockUserAgent(t *testing.T, m *mockAPI) *plextrac.UserAgent {
+	t.Helper()
+
+	server, httpClient := testServerWithHandler(t, m.handler(t))
+	t.Cleanup(server.Close)
+
+	ua, _, err := plextrac.New(plextrac.NewOptions{
+		InstanceURL: server.Listener.Addr().String(),
+		AuthToken:   genJWT(time.Now().Add(24 * time.Hour)),
+		HTTPClient:  httpClient,
+	})
+	if err != nil {
+		t.Fatalf("New() returned error: %v", err)
+	}
+
+	return ua
+}
+
+// mockClient starts a server for the mock API and returns its client.
+func mockClient(t *testing.T, m *mockAPI) *plextrac.Client {
+	t.Helper()
+
+	c, err := mockUserAgent(t, m).ClientByPartial("test")
+	if err != nil {
+		t.Fatalf("ClientByPartial() returned error: %v", err)
+	}
+
+	return c
+}
+
+// mockReport starts a server for the mock API and returns its report.
+func mockReport(t *testing.T, m *mockAPI) *plextrac.Report {
+	t.Helper()
+
+	r, _, err := mockClient(t, m).ReportByPartial("test")
+	if err != nil {
+		t.Fatalf("ReportByPartial() returned error: %v", err)
+	}
+
+	return r
+}
+
+func TestReport_AddFindingFromWriteup(t *testing.T) {
+	t.Parallel()
+
+	m := newMockAPI(map[string]mockRoute{
+		"POST /api/v1/client/123/report/456/flaw/create": func(t *testing.T, body map[string]any) any {
+			t.Helper()
+
+			return map[string]any{"status": "success", "flaw_id": 789}
+		},
+	})
+	r := mockReport(t, m)
+
+	w := &plextrac.Writeup{
+		ID:              "writeup-1",
+		Title:           "LLMNR Poisoning",
+		Severity:        "High",
+		Description:     "<p>Description</p>",
+		Recommendations: "<p>Disable LLMNR</p>",
+		References:      "<p>https://attack.mitre.org/techniques/T1557/001/</p>",
+		Tags:            []string{"network"},
+	}
+	w.Fields.CustomField1.Key = "custom_field_1"
+	w.Fields.CustomField1.Value = "custom value"
+
+	f, _, err := r.AddFindingFromWriteup(w, plextrac.FindingOverrides{Severity: "Medium"})
+	if err != nil {
+		t.Fatalf("AddFindingFromWriteup() returned error: %v", err)
+	}
+
+	if f.ID != 789 {
+		t.Errorf("expected finding ID 789, got %d", f.ID)
+	}
+
+	if f.Name != "LLMNR Poisoning" {
+		t.Errorf("expected finding name from writeup, got %q", f.Name)
+	}
+
+	if f.Severity != "Medium" {
+		t.Errorf("expected overridden severity Medium, got %q", f.Severity)
+	}
+
+	requests := m.requestsFor("POST /api/v1/client/123/report/456/flaw/create")
+	if len(requests) != 1 {
+		t.Fatalf("expected one create request, got %d", len(requests))
+	}
+
+	doc := requests[0]
+
+	for k, v := range map[string]string{
+		"title":           "LLMNR Poisoning",
+		"severity":        "Medium",
+		"status":          "Open",
+		"visibility":      "draft",
+		"description":     "<p>Description</p>",
+		"recommendations": "<p>Disable LLMNR</p>",
+		"references":      "<p>https://attack.mitre.org/techniques/T1557/001/</p>",
+		"writeupID":       "writeup-1",
+	} {
+		if doc[k] != v {
+			t.Errorf("expected %s to be %q, got %#v", k, v, doc[k])
+		}
+	}
+
+	fields, ok := doc["fields"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected fields to be a map, got %#v", doc["fields"])
+	}
+
+	cf, ok := fields["custom_field_1"].(map[string]any)
+	if !ok || cf["value"] != "custom value" {
+		t.Errorf("expected custom field to be copied, got %#v", fields["custom_field_1"])
+	}
+
+	if len(fields) != 1 {
+		t.Errorf("expected only the custom field that's set, got %#v", fields)
+	}
+}
+
+func TestReport_AddFindingFromWriteup_keeps_fields(t *testing.T) {
+	t.Parallel()
+
+	m := newMockAPI(map[string]mockRoute{
+		"POST /api/v1/client/123/report/456/flaw/create": func(t *testing.T, body map[string]any) any {
+			t.Helper()
+
+			return map[string]any{"status": "success", "flaw_id": 789}
+		},
+	})
+	r := mockReport(t, m)
+
+	var w plextrac.Writeup
+
+	err := json.Unmarshal([]byte(`{"id": "writeup-1", "title": "LLMNR Poisoning", "severity": "High", "fields": {
+		"attack_path": {"key": "attack_path", "label": "Attack Path", "sort_order": 3, "value": "Internal"},
+		"custom_field_1": {"key": "custom_field_1", "value": "custom value"}
+	}}`), &w)
+	if err != nil {
+		t.Fatalf("Unmarshal() returned error: %v", err)
+	}
+
+	_, _, err = r.AddFindingFromWriteup(&w, plextrac.FindingOverrides{})
+	if err != nil {
+		t.Fatalf("AddFindingFromWriteup() returned error: %v", err)
+	}
+
+	requests := m.requestsFor("POST /api/v1/client/123/report/456/flaw/create")
+	if len(requests) != 1 {
+		t.Fatalf("expected one create request, got %d", len(requests))
+	}
+
+	fields, _ := requests[0]["fields"].(map[string]any)
+	if path, _ := fields["attack_path"].(map[string]any); path["value"] != "Internal" || path["label"] != "Attack Path" {
+		t.Errorf("expected the writeup's own field to be copied, got %#v", fields)
+	}
+
+	if len(fields) != 2 {
+		t.Errorf("expected the writeup's fields as they are, got %#v", fields)
+	}
+}
+
+func TestReport_AddFindingFromWriteup_checks_overrides(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		overrides plextrac.FindingOverrides
+	}{
+		{"severity", plextrac.FindingOverrides{Severity: "Severe"}},
+		{"status", plextrac.FindingOverrides{Status: "Done"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := newMockAPI(nil)
+			r := mockReport(t, m)
+
+			_, _, err := r.AddFindingFromWriteup(&plextrac.Writeup{Title: "LLMNR Poisoning"}, tt.overrides)
+			if err == nil {
+				t.Fatal("expected an error for a bad override")
+			}
+
+			if len(m.requestsFor("POST /api/v1/client/123/report/456/flaw/create")) != 0 {
+				t.Error("expected nothing to be created")
+			}
+		})
+	}
+}
+
+func TestReport_CreateFinding_requires_title(t *testing.T) {
+	t.Parallel()
+
+	r := mockReport(t, newMockAPI(nil))
+
+	_, _, err := r.CreateFinding(map[string]any{})
+	if err == nil {
+		t.Fatal("expected an error for a finding without a title")
+	}
+}
+
+func TestReport_CreateFinding_handles_failure(t *testing.T) {
+	t.Parallel()
+
+	m := newMockAPI(map[string]mockRoute{
+		"POST /api/v1/client/123/report/456/flaw/create": func(t *testing.T, body map[string]any) any {
+			t.Helper()
+
+			return map[string]any{"status": "error", "message": "nope"}
+		},
+	})
+	r := mockReport(t, m)
+
+	_, _, err := r.CreateFinding(map[string]any{"title": "x"})
+	if err == nil {
+		t.Fatal("expected an error when PlexTrac rejects the finding")
+	}
+}
+
+// findingRoutes serves a single finding with the given raw document, and
+// accepts updates to it.
+func findingRoutes(raw map[string]any) map[string]mockRoute {
+	return map[string]mockRoute{
+		"GET /api/v1/client/123/report/456/flaws": func(t *testing.T, body map[string]any) any {
+			t.Helper()
+
+			return []map[string]any{
+				{
+					"id":     "flaw_789",
+					"doc_id": []string{"789"},
+					"data": []any{
+						789, raw["severity"], raw["title"], raw["status"], 0, nil, 0, nil, 0, nil, raw["visibility"], "",
+					},
+				},
+			}
+		},
+		"GET /api/v1/client/123/report/456/flaw/789": func(t *testing.T, body map[string]any) any {
+			t.Helper()
+
+			return raw
+		},
+		"PUT /api/v1/client/123/report/456/flaw/789": func(t *testing.T, body map[string]any) any {
+			t.Helper()
+
+			return map[string]any{"status": "success"}
+		},
+	}
+}
+
+func testFindingRaw() map[string]any {
+	return map[string]any{
+		"title":           "Test Finding",
+		"severity":        "High",
+		"status":          "Open",
+		"visibility":      "draft",
+		"description":     "<p>desc</p>",
+		"recommendations": "<p>recs</p>",
+		"references":      "",
+		"tags":            []any{"scope_ipt"},
+		"affected_assets": map[string]any{},
+		"fields": map[string]any{
+			"evidence":  map[string]any{"value": "<p>evidence</p>"},
+			"plugin_id": map[string]any{"key": "plugin_id", "label": "Plugin ID", "value": "57608"},
+		},
+	}
+}
+
+// mockFinding returns the only finding of the mock report.
+func mockFinding(t *testing.T, m *mockAPI) *plextrac.Finding {
+	t.Helper()
+
+	r := mockReport(t, m)
+
+	f, err := r.FindingByPartial("test")
+	if err != nil {
+		t.Fatalf("FindingByPartial() returned error: %v", err)
+	}
+
+	return f
+}
+
+func TestFinding_EnsureFull_parses_rich_text(t *testing.T) {
+	t.Parallel()
+
+	f := mockFinding(t, newMockAPI(findingRoutes(testFindingRaw())))
+
+	_, err := f.EnsureFull()
+	if err != nil {
+		t.Fatalf("EnsureFull() returned error: %v", err)
+	}
+
+	if f.Description != "<p>desc</p>" {
+		t.Errorf("expected description, got %q", f.Description)
+	}
+
+	if f.Recommendations != "<p>recs</p>" {
+		t.Errorf("expected recommendations, got %q", f.Recommendations)
+	}
+
+	if f.Evidence != "<p>evidence</p>" {
+		t.Errorf("expected evidence, got %q", f.Evidence)
+	}
+}
+
+func TestFinding_setters(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		set   func(f *plextrac.Finding) ([]error, error)
+		key   string
+		value any
+	}{
+		{
+			name:  "severity is normalized",
+			set:   func(f *plextrac.Finding) ([]error, error) { return f.SetSeverity("critical") },
+			key:   "severity",
+			value: "Critical",
+		},
+		{
+			name:  "status",
+			set:   func(f *plextrac.Finding) ([]error, error) { return f.SetStatus("in process") },
+			key:   "status",
+			value: "In Process",
+		},
+		{
+			name:  "published",
+			set:   func(f *plextrac.Finding) ([]error, error) { return f.SetPublished(true) },
+			key:   "visibility",
+			value: "published",
+		},
+		{
+			name:  "title",
+			set:   func(f *plextrac.Finding) ([]error, error) { return f.SetTitle("New Title") },
+			key:   "title",
+			value: "New Title",
+		},
+		{
+			name:  "description",
+			set:   func(f *plextrac.Finding) ([]error, error) { return f.SetDescription("<p>new</p>") },
+			key:   "description",
+			value: "<p>new</p>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := newMockAPI(findingRoutes(testFindingRaw()))
+			f := mockFinding(t, m)
+
+			_, err := tt.set(f)
+			if err != nil {
+				t.Fatalf("setter returned error: %v", err)
+			}
+
+			puts := m.requestsFor("PUT /api/v1/client/123/report/456/flaw/789")
+			if len(puts) != 1 {
+				t.Fatalf("expected one update, got %d", len(puts))
+			}
+
+			if puts[0][tt.key] != tt.value {
+				t.Errorf("expected %s to be %#v, got %#v", tt.key, tt.value, puts[0][tt.key])
+			}
+
+			// The rest of the document should be sent back untouched
+			if puts[0]["recommendations"] != "<p>recs</p>" {
+				t.Errorf("expected the rest of the finding to be kept, got %#v", puts[0])
+			}
+		})
+	}
+}
+
+func TestFinding_Update(t *testing.T) {
+	t.Parallel()
+
+	m := newMockAPI(findingRoutes(testFindingRaw()))
+	f := mockFinding(t, m)
+
+	title, severity, evidence, published := "New Title", "low", "<p>new evidence</p>", true
+
+	_, err := f.Update(plextrac.FindingUpdate{
+		Title:     &title,
+		Severity:  &severity,
+		Evidence:  &evidence,
+		Published: &published,
+	})
+	if err != nil {
+		t.Fatalf("Update() returned error: %v", err)
+	}
+
+	puts := m.requestsFor("PUT /api/v1/client/123/report/456/flaw/789")
+	if len(puts) != 1 {
+		t.Fatalf("expected one update, got %d", len(puts))
+	}
+
+	for key, value := range map[string]any{"title": "New Title", "severity": "Low", "visibility": "published", "status": "Open"} {
+		if puts[0][key] != value {
+			t.Errorf("expected %s to be %#v, got %#v", key, value, puts[0][key])
+		}
+	}
+
+	if f.Evidence != evidence || f.Name != title || f.Severity != "Low" || !f.Published {
+		t.Errorf("expected the finding to be updated, got %+v", f)
+	}
+}
+
+func TestFinding_Update_checks_first(t *testing.T) {
+	t.Parallel()
+
+	m := newMockAPI(findingRoutes(testFindingRaw()))
+	f := mockFinding(t, m)
+
+	title, status := "New Title", "bogus"
+
+	_, err := f.Update(plextrac.FindingUpdate{Title: &title, Status: &status})
+	if err == nil {
+		t.Fatal("expected an error for an unknown status")
+	}
+
+	if puts := m.requestsFor("PUT /api/v1/client/123/report/456/flaw/789"); len(puts) != 0 {
+		t.Errorf("expected no updates, got %d", len(puts))
+	}
+
+	if f.Name == title {
+		t.Error("expected the title to be left alone")
+	}
+}
+
+func TestFinding_SetSeverity_rejects_unknown(t *testing.T) {
+	t.Parallel()
+
+	m := newMockAPI(findingRoutes(testFindingRaw()))
+	f := mockFinding(t, m)
+
+	_, err := f.SetSeverity("Spicy")
+	if err == nil {
+		t.Fatal("expected an error for an unknown severity")
+	}
+
+	if len(m.requestsFor("PUT /api/v1/client/123/report/456/flaw/789")) != 0 {
+		t.Fatal("expected no update for an unknown severity")
+	}
+}
+
+func TestFinding_SetEvidence(t *testing.T) {
+	t.Parallel()
+
+	m := newMockAPI(findingRoutes(testFindingRaw()))
+	f := mockFinding(t, m)
+
+	_, err := f.SetEvidence("<figure></figure>")
+	if err != nil {
+		t.Fatalf("SetEvidence() returned error: %v", err)
+	}
+
+	puts := m.requestsFor("PUT /api/v1/client/123/report/456/flaw/789")
+	if len(puts) != 1 {
+		t.Fatalf("expected one update, got %d", len(puts))
+	}
+
+	fields, _ := puts[0]["fields"].(map[string]any)
+	evidence, _ := fields["evidence"].(map[string]any)
+
+	if evidence["value"] != "<figure></figure>" {
+		t.Errorf("expected evidence to be updated, got %#v", puts[0]["fields"])
+	}
+}
+
+func TestFinding_AppendEvidence(t *testing.T) {
+	t.Parallel()
+
+	m := newMockAPI(findingRoutes(testFindingRaw()))
+	f := mockFinding(t, m)
+
+	_, err := f.AppendEvidence("<pre><code>id</code></pre>")
+	if err != nil {
+		t.Fatalf("AppendEvidence() returned error: %v", err)
+	}
+
+	puts := m.requestsFor("PUT /api/v1/client/123/report/456/flaw/789")
+	if len(puts) != 1 {
+		t.Fatalf("expected one update, got %d", len(puts))
+	}
+
+	fields, _ := puts[0]["fields"].(map[string]any)
+	evidence, _ := fields["evidence"].(map[string]any)
+
+	if evidence["value"] != "<p>evidence</p><pre><code>id</code></pre>" {
+		t.Errorf("expected evidence to be appended to, got %#v", evidence["value"])
+	}
+}
+
+func TestFinding_Field(t *testing.T) {
+	t.Parallel()
+
+	f := mockFinding(t, newMockAPI(findingRoutes(testFindingRaw())))
+
+	if got := f.Field("plugin_id"); got != "57608" {
+		t.Errorf("Field(plugin_id) = %q, want 57608", got)
+	}
+
+	if got := f.Field("missing"); got != "" {
+		t.Errorf("expected an empty missing field, got %q", got)
+	}
+}
+
+func TestNewFinding(t *testing.T) {
+	t.Parallel()
+
+	raw := testFindingRaw()
+	raw["risk_score"] = map[string]any{"CVSS3": map[string]any{"overall": 9.8, "vector": "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H"}}
+
+	f, _, err := plextrac.NewFinding(raw)
+	if err != nil {
+		t.Fatalf("NewFinding() returned error: %v", err)
+	}
+
+	if f.Name != "Test Finding" || f.Description != "<p>desc</p>" {
+		t.Errorf("unexpected finding: %+v", f)
+	}
+
+	if got := f.CVSS3(); got != "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H" {
+		t.Errorf("CVSS3() = %q", got)
+	}
+}
+
+func TestFinding_CVSS3(t *testing.T) {
+	t.Parallel()
+
+	vector := "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H"
+
+	riskScore := testFindingRaw()
+	riskScore["risk_score"] = map[string]any{"CVSS3": map[string]any{"overall": 9.8, "vector": vector}}
+
+	scores := testFindingRaw()
+	scores["fields"].(map[string]any)["scores"] = map[string]any{"cvss3": map[string]any{"calculation": vector}}
+	scores["writeupID"] = "writeup-1"
+
+	tests := []struct {
+		name      string
+		raw       map[string]any
+		vector    string
+		writeupID string
+	}{
+		{"risk score", riskScore, vector, ""},
+		{"writeup scores", scores, vector, "writeup-1"},
+		{"none", testFindingRaw(), "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := mockFinding(t, newMockAPI(findingRoutes(tt.raw)))
+
+			if got := f.CVSS3(); got != tt.vector {
+				t.Errorf("CVSS3() = %q, want %q", got, tt.vector)
+			}
+
+			if got := f.WriteupID(); got != tt.writeupID {
+				t.Errorf("WriteupID() = %q, want %q", got, tt.writeupID)
+			}
+		})
+	}
+}
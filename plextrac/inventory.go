@@ -0,0 +1,511 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package plextrac
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// InventoryAsset is an asset in a client's asset inventory, as opposed to
+// an Asset, which is that asset's instance on a finding.
+type InventoryAsset struct {
+	raw map[string]any
+
+	ID       string   `json:"id,omitempty"`
+	Asset    string   `json:"asset"`
+	Type     string   `json:"type,omitempty"`
+	Hostname string   `json:"hostname,omitempty"`
+	IPs      []string `json:"ips,omitempty"`
+	OS       string   `json:"os,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	Findings []string `json:"findings,omitempty"`
+}
+
+// InventoryChanges describes what ImportInventory did, or would do.
+type InventoryChanges struct {
+	Created   []*InventoryAsset
+	Updated   []*InventoryAsset
+	Unchanged []*InventoryAsset
+}
+
+// NormalizeHost lowercases host names and strips their trailing dot, and
+// puts IP addresses in their canonical form.
+func NormalizeHost(host string) string {
+	host = strings.TrimSpace(host)
+
+	if ip := net.ParseIP(host); ip != nil {
+		return ip.String()
+	}
+
+	return strings.TrimSuffix(strings.ToLower(host), ".")
+}
+
+// Normalize cleans up the names and addresses of the asset. When the asset
+// is known by an IP address, it's added to the IPs, and when it's known by a
+// host name, that becomes the Hostname if there isn't one.
+func (a *InventoryAsset) Normalize() {
+	a.Asset = NormalizeHost(a.Asset)
+	a.Hostname = NormalizeHost(a.Hostname)
+
+	var ips []string
+
+	for _, ip := range a.IPs {
+		ip = NormalizeHost(ip)
+		if ip != "" && !slices.Contains(ips, ip) {
+			ips = append(ips, ip)
+		}
+	}
+
+	if net.ParseIP(a.Asset) != nil {
+		if !slices.Contains(ips, a.Asset) {
+			ips = append([]string{a.Asset}, ips...)
+		}
+	} else if a.Hostname == "" {
+		a.Hostname = a.Asset
+	}
+
+	if a.Asset == "" {
+		a.Asset = a.Hostname
+	}
+
+	if a.Asset == "" && len(ips) > 0 {
+		a.Asset = ips[0]
+	}
+
+	a.IPs = ips
+
+	var tags []string
+
+	for _, t := range a.Tags {
+		t = strings.TrimSpace(t)
+		if t != "" && !slices.Contains(tags, t) {
+			tags = append(tags, t)
+		}
+	}
+
+	a.Tags = tags
+}
+
+// normalized is a normalized copy of the asset, to compare it without
+// changing it.
+func (a *InventoryAsset) normalized() *InventoryAsset {
+	n := *a
+	n.IPs = slices.Clone(a.IPs)
+	n.Tags = slices.Clone(a.Tags)
+	n.Normalize()
+
+	return &n
+}
+
+// names are everything the asset can be known by.
+func (a *InventoryAsset) names() []string {
+	names := append([]string{a.Asset, a.Hostname}, a.IPs...)
+
+	return slices.DeleteFunc(names, func(n string) bool {
+		return n == ""
+	})
+}
+
+// Same reports whether both assets refer to the same host, by name or by
+// address.
+func (a *InventoryAsset) Same(b *InventoryAsset) bool {
+	for _, n := range a.names() {
+		if slices.Contains(b.names(), n) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// merge fills in what b knows about the asset and a doesn't, returning
+// whether a changed.
+func (a *InventoryAsset) merge(b *InventoryAsset) bool {
+	changed := false
+
+	if a.Type == "" && b.Type != "" {
+		a.Type = b.Type
+		changed = true
+	}
+
+	if a.Hostname == "" && b.Hostname != "" {
+		a.Hostname = b.Hostname
+		changed = true
+	}
+
+	if a.OS == "" && b.OS != "" {
+		a.OS = b.OS
+		changed = true
+	}
+
+	for _, ip := range b.IPs {
+		if !slices.ContainsFunc(a.IPs, func(known string) bool { return NormalizeHost(known) == NormalizeHost(ip) }) {
+			a.IPs = append(a.IPs, ip)
+			changed = true
+		}
+	}
+
+	for _, t := range b.Tags {
+		if !slices.Contains(a.Tags, t) {
+			a.Tags = append(a.Tags, t)
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+// DedupeInventory normalizes the assets and merges the ones that refer to
+// the same host, keeping the first of them.
+func DedupeInventory(assets []*InventoryAsset) []*InventoryAsset {
+	var deduped []*InventoryAsset
+
+	for _, a := range assets {
+		a.Normalize()
+
+		if a.Asset == "" {
+			continue
+		}
+
+		i := slices.IndexFunc(deduped, a.Same)
+		if i == -1 {
+			deduped = append(deduped, a)
+
+			continue
+		}
+
+		deduped[i].merge(a)
+	}
+
+	return deduped
+}
+
+func inventoryAssetFromMap(m map[string]any) (*InventoryAsset, []error) {
+	var warnings []error
+
+	a := &InventoryAsset{
+		raw: m,
+	}
+
+	a.ID, _ = m["id"].(string)
+	if a.ID == "" {
+		warnings = append(warnings, fmt.Errorf("unable to coerce asset id into string: %#v", m["id"]))
+	}
+
+	a.Asset, _ = m["asset"].(string)
+	a.Type, _ = m["type"].(string)
+	a.Hostname, _ = m["hostname"].(string)
+
+	for k, v := range map[string]*[]string{
+		"knownIps": &a.IPs,
+		"tags":     &a.Tags,
+	} {
+		if m[k] == nil {
+			continue
+		}
+
+		list, ok := m[k].([]any)
+		if !ok {
+			warnings = append(warnings, fmt.Errorf("unable to coerce %s %#v into a []string", k, m[k]))
+
+			continue
+		}
+
+		for _, item := range list {
+			if s, ok := item.(string); ok {
+				*v = append(*v, s)
+			}
+		}
+	}
+
+	// PlexTrac allows several, but one is all anyone ever knows
+	switch os := m["operating_system"].(type) {
+	case string:
+		a.OS = os
+	case []any:
+		if len(os) > 0 {
+			a.OS, _ = os[0].(string)
+		}
+	}
+
+	return a, warnings
+}
+
+func (a *InventoryAsset) toMap() map[string]any {
+	m := make(map[string]any)
+	for k, v := range a.raw {
+		m[k] = v
+	}
+
+	m["asset"] = a.Asset
+	m["type"] = a.Type
+	m["hostname"] = a.Hostname
+	m["knownIps"] = a.IPs
+	m["tags"] = a.Tags
+
+	if a.IPs == nil {
+		m["knownIps"] = []string{}
+	}
+
+	if a.Tags == nil {
+		m["tags"] = []string{}
+	}
+
+	m["operating_system"] = []string{}
+	if a.OS != "" {
+		m["operating_system"] = []string{a.OS}
+	}
+
+	return m
+}
+
+// Inventory returns the client's asset inventory.
+func (c *Client) Inventory() ([]*InventoryAsset, []error, error) {
+	var warnings []error
+
+	var resp any
+
+	path := fmt.Sprintf("v1/client/%d/assets", c.ID)
+
+	_, err := c.ua.apiGet(path, &resp)
+	if err != nil {
+		return nil, warnings, fmt.Errorf("unable to get assets: %w", err)
+	}
+
+	// Depending on the instance, this is a list of assets or a map of them
+	// by id
+	var docs []any
+
+	switch r := resp.(type) {
+	case []any:
+		docs = r
+	case map[string]any:
+		for _, d := range r {
+			docs = append(docs, d)
+		}
+	case nil:
+	default:
+		return nil, warnings, fmt.Errorf("unable to coerce assets %T into a list", resp)
+	}
+
+	var assets []*InventoryAsset
+
+	for _, d := range docs {
+		m, ok := d.(map[string]any)
+		if !ok {
+			warnings = append(warnings, errors.New("unable to coerce asset into map[string]interface{}"))
+
+			continue
+		}
+
+		a, assetWarnings := inventoryAssetFromMap(m)
+		warnings = append(warnings, assetWarnings...)
+		assets = append(assets, a)
+	}
+
+	slices.SortFunc(assets, func(a, b *InventoryAsset) int {
+		return strings.Compare(a.Asset, b.Asset)
+	})
+
+	return assets, warnings, nil
+}
+
+// InventoryFindings fills in the Findings of each asset with the
+// "report / finding" names referencing it. This has to look at every
+// finding of every report of the client, so it's slow.
+func (c *Client) InventoryFindings(assets []*InventoryAsset) ([]error, error) {
+	reports, warnings, err := c.Reports()
+	if err != nil {
+		return warnings, err
+	}
+
+	byID := make(map[string]*InventoryAsset)
+	for _, a := range assets {
+		byID[a.ID] = a
+	}
+
+	for _, r := range reports {
+		findings, findingsWarnings, err := r.Findings()
+		warnings = append(warnings, findingsWarnings...)
+
+		if err != nil {
+			return warnings, err
+		}
+
+		for _, f := range findings {
+			findingAssets, assetWarnings, err := f.Assets()
+			warnings = append(warnings, assetWarnings...)
+
+			if err != nil {
+				warnings = append(warnings, fmt.Errorf("%s / %s: %w", r.Name, f.Name, err))
+
+				continue
+			}
+
+			for _, fa := range findingAssets {
+				a, ok := byID[fa.ID]
+				if !ok {
+					continue
+				}
+
+				name := r.Name + " / " + f.Name
+				if !slices.Contains(a.Findings, name) {
+					a.Findings = append(a.Findings, name)
+				}
+			}
+		}
+	}
+
+	return warnings, nil
+}
+
+// ImportInventory adds the assets to the client's inventory. They're
+// deduplicated amongst themselves and against the existing inventory by
+// host name and address. Existing assets only get the details they're
+// missing filled in. With dryRun, nothing is changed in PlexTrac.
+func (c *Client) ImportInventory(assets []*InventoryAsset, dryRun bool) (InventoryChanges, []error, error) {
+	var changes InventoryChanges
+
+	existing, warnings, err := c.Inventory()
+	if err != nil {
+		return changes, warnings, err
+	}
+
+	// Existing assets are compared normalized, but written back as they
+	// were, so only what the import fills in changes
+	var normalized []*InventoryAsset
+	for _, e := range existing {
+		normalized = append(normalized, e.normalized())
+	}
+
+	for _, a := range DedupeInventory(assets) {
+		i := slices.IndexFunc(normalized, a.Same)
+		if i == -1 {
+			changes.Created = append(changes.Created, a)
+
+			continue
+		}
+
+		// Several assets can match the same existing one, eg: one by host
+		// name and another by address, which is still one update
+		e := existing[i]
+
+		if normalized[i].merge(a) {
+			e.merge(a)
+
+			changes.Unchanged = slices.DeleteFunc(changes.Unchanged, func(u *InventoryAsset) bool { return u == e })
+			if !slices.Contains(changes.Updated, e) {
+				changes.Updated = append(changes.Updated, e)
+			}
+		} else if !slices.Contains(changes.Updated, e) && !slices.Contains(changes.Unchanged, e) {
+			changes.Unchanged = append(changes.Unchanged, e)
+		}
+	}
+
+	if dryRun {
+		return changes, warnings, nil
+	}
+
+	return changes, warnings, c.writeInventory(changes)
+}
+
+// AddAssets adds the assets to the client's inventory through the compare
+// and bulk endpoints, as AddAssetBulk does, rather than getting the whole
+// inventory. Assets are deduplicated amongst themselves, but only match
+// existing assets by name. Existing assets only get the details they're
+// missing filled in. With dryRun, nothing is changed in PlexTrac.
+func (c *Client) AddAssets(assets []*InventoryAsset, dryRun bool) (InventoryChanges, []error, error) {
+	var changes InventoryChanges
+
+	deduped := DedupeInventory(assets)
+
+	var values []string
+	for _, a := range deduped {
+		values = append(values, a.Asset)
+	}
+
+	existingDocs, newDocs, warnings, err := c.compareAssets(values)
+	if err != nil {
+		return changes, warnings, err
+	}
+
+	byValue := func(docs []map[string]any, value string) map[string]any {
+		for _, doc := range docs {
+			if v, _ := doc["asset"].(string); NormalizeHost(v) == value {
+				return doc
+			}
+		}
+
+		return nil
+	}
+
+	for _, a := range deduped {
+		doc := byValue(existingDocs, a.Asset)
+		if doc == nil {
+			// Keep whatever PlexTrac filled in for the new asset
+			a.raw = byValue(newDocs, a.Asset)
+			changes.Created = append(changes.Created, a)
+
+			continue
+		}
+
+		e, assetWarnings := inventoryAssetFromMap(doc)
+		warnings = append(warnings, assetWarnings...)
+
+		if e.normalized().merge(a) {
+			e.merge(a)
+			changes.Updated = append(changes.Updated, e)
+		} else {
+			changes.Unchanged = append(changes.Unchanged, e)
+		}
+	}
+
+	if dryRun {
+		return changes, warnings, nil
+	}
+
+	return changes, warnings, c.writeInventory(changes)
+}
+
+// writeInventory creates the new assets in bulk, giving them their IDs, and
+// updates the changed ones.
+func (c *Client) writeInventory(changes InventoryChanges) error {
+	if len(changes.Created) > 0 {
+		var docs []map[string]any
+		for _, a := range changes.Created {
+			docs = append(docs, a.toMap())
+		}
+
+		created, err := c.createAssets(docs)
+		if err != nil {
+			return err
+		}
+
+		for _, doc := range created {
+			id, _ := doc["id"].(string)
+			value, _ := doc["asset"].(string)
+
+			for _, a := range changes.Created {
+				if a.Asset == NormalizeHost(value) {
+					a.ID = id
+				}
+			}
+		}
+	}
+
+	for _, a := range changes.Updated {
+		path := fmt.Sprintf("v1/client/%d/asset/%s", c.ID, a.ID)
+
+		body, err := c.ua.apiCall(http.MethodPut, path, a.toMap(), nil)
+		if err != nil {
+			return fmt.Errorf("error updating asset %s: %w: %s", a.Asset, err, body)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,363 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package plextrac_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/brimstone/plextraccli/plextrac"
+)
+
+func TestNormalizeHost(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in       string
+		expected string
+	}{
+		{"DC01.Corp.Local", "dc01.corp.local"},
+		{"dc01.corp.local.", "dc01.corp.local"},
+		{" 10.0.0.5 ", "10.0.0.5"},
+		{"FE80:0000::0001", "fe80::1"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			t.Parallel()
+
+			got := plextrac.NormalizeHost(tt.in)
+			if got != tt.expected {
+				t.Errorf("NormalizeHost(%q) = %q, want %q", tt.in, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDedupeInventory(t *testing.T) {
+	t.Parallel()
+
+	deduped := plextrac.DedupeInventory([]*plextrac.InventoryAsset{
+		{Asset: "DC01.corp.local", IPs: []string{"10.0.0.5"}},
+		{Asset: "10.0.0.5", OS: "Windows Server 2019", Tags: []string{"dc"}},
+		{Asset: "dc01.corp.local.", Type: "Server"},
+		{Hostname: "WS01.corp.local"},
+		{Asset: "10.0.0.9"},
+		{},
+	})
+
+	if len(deduped) != 3 {
+		t.Fatalf("expected 3 assets, got %d: %#v", len(deduped), deduped)
+	}
+
+	dc01 := deduped[0]
+	if dc01.Asset != "dc01.corp.local" || dc01.Hostname != "dc01.corp.local" {
+		t.Errorf("unexpected names for dc01: %#v", dc01)
+	}
+
+	if dc01.OS != "Windows Server 2019" || dc01.Type != "Server" || !slices.Equal(dc01.Tags, []string{"dc"}) {
+		t.Errorf("expected dc01 to get the details of its duplicates: %#v", dc01)
+	}
+
+	if deduped[1].Asset != "ws01.corp.local" {
+		t.Errorf("expected the hostname to become the asset, got %#v", deduped[1])
+	}
+
+	if !slices.Equal(deduped[2].IPs, []string{"10.0.0.9"}) || deduped[2].Hostname != "" {
+		t.Errorf("expected an IP asset to be in its IPs, got %#v", deduped[2])
+	}
+}
+
+// inventoryRoutes serves a client inventory with dc01, and accepts new and
+// updated assets.
+func inventoryRoutes() map[string]mockRoute {
+	return map[string]mockRoute{
+		"GET /api/v1/client/123/assets": func(t *testing.T, body map[string]any) any {
+			t.Helper()
+
+			return []map[string]any{
+				{
+					"id":               "asset-dc01",
+					"asset":            "dc01.corp.local",
+					"type":             "Server",
+					"knownIps":         []string{"10.0.0.5"},
+					"operating_system": []string{"Windows Server 2019"},
+					"tags":             []string{"dc"},
+					"description":      "keep me",
+				},
+				{"id": "asset-ws01", "asset": "ws01.corp.local"},
+			}
+		},
+		"POST /api/v2/client/123/bulk/assets": func(t *testing.T, body map[string]any) any {
+			t.Helper()
+
+			requested, _ := body["assets"].([]any)
+
+			var assets []map[string]any
+
+			for _, r := range requested {
+				a, _ := r.(map[string]any)
+				value, _ := a["asset"].(string)
+				assets = append(assets, map[string]any{"id": "asset-new-" + value, "asset": value})
+			}
+
+			return map[string]any{"status": "success", "assets": assets}
+		},
+		"PUT /api/v1/client/123/asset/asset-ws01": func(t *testing.T, body map[string]any) any {
+			t.Helper()
+
+			return map[string]any{"status": "success"}
+		},
+	}
+}
+
+func TestClient_Inventory(t *testing.T) {
+	t.Parallel()
+
+	c := mockClient(t, newMockAPI(inventoryRoutes()))
+
+	inventory, warnings, err := c.Inventory()
+	if err != nil {
+		t.Fatalf("Inventory() returned error: %v", err)
+	}
+
+	if len(warnings) != 0 {
+		t.Errorf("unexpected warnings: %v", warnings)
+	}
+
+	if len(inventory) != 2 {
+		t.Fatalf("expected 2 assets, got %#v", inventory)
+	}
+
+	dc01 := inventory[0]
+	if dc01.ID != "asset-dc01" || dc01.Type != "Server" || dc01.OS != "Windows Server 2019" ||
+		!slices.Equal(dc01.IPs, []string{"10.0.0.5"}) || !slices.Equal(dc01.Tags, []string{"dc"}) {
+		t.Errorf("unexpected asset: %#v", dc01)
+	}
+}
+
+func TestClient_ImportInventory(t *testing.T) {
+	t.Parallel()
+
+	m := newMockAPI(inventoryRoutes())
+	c := mockClient(t, m)
+
+	changes, _, err := c.ImportInventory([]*plextrac.InventoryAsset{
+		{Asset: "10.0.0.5", OS: "Windows 10"},
+		{Asset: "WS01.corp.local", OS: "Windows 11"},
+		{Asset: "web01.corp.local", Type: "Server"},
+		{Asset: "web01.corp.local.", IPs: []string{"10.0.0.80"}},
+	}, false)
+	if err != nil {
+		t.Fatalf("ImportInventory() returned error: %v", err)
+	}
+
+	if len(changes.Created) != 1 || len(changes.Updated) != 1 || len(changes.Unchanged) != 1 {
+		t.Fatalf("expected 1 created, 1 updated and 1 unchanged, got %#v", changes)
+	}
+
+	creates := m.requestsFor("POST /api/v2/client/123/bulk/assets")
+	if len(creates) != 1 {
+		t.Fatalf("expected one bulk create, got %d", len(creates))
+	}
+
+	requested, _ := creates[0]["assets"].([]any)
+	if len(requested) != 1 {
+		t.Fatalf("expected web01 to be created once, got %#v", requested)
+	}
+
+	web01, _ := requested[0].(map[string]any)
+	if web01["asset"] != "web01.corp.local" || web01["type"] != "Server" {
+		t.Errorf("unexpected new asset: %#v", web01)
+	}
+
+	if changes.Created[0].ID != "asset-new-web01.corp.local" {
+		t.Errorf("expected the new asset to get its id, got %q", changes.Created[0].ID)
+	}
+
+	puts := m.requestsFor("PUT /api/v1/client/123/asset/asset-ws01")
+	if len(puts) != 1 {
+		t.Fatalf("expected ws01 to be updated, got %d updates", len(puts))
+	}
+
+	if os, _ := puts[0]["operating_system"].([]any); len(os) != 1 || os[0] != "Windows 11" {
+		t.Errorf("expected ws01 to get an OS, got %#v", puts[0])
+	}
+}
+
+func TestClient_ImportInventory_keeps_existing(t *testing.T) {
+	t.Parallel()
+
+	routes := inventoryRoutes()
+	routes["GET /api/v1/client/123/assets"] = func(t *testing.T, body map[string]any) any {
+		t.Helper()
+
+		return []map[string]any{
+			{"id": "asset-dc01", "asset": "DC01.Corp.Local.", "type": "Server"},
+			{"id": "asset-ws01", "asset": "WS01.Corp.Local.", "hostname": "WS01.Corp.Local.", "knownIps": []string{"10.0.0.7"}},
+		}
+	}
+
+	m := newMockAPI(routes)
+	c := mockClient(t, m)
+
+	// Both match ws01, one by host name and one by address
+	changes, _, err := c.ImportInventory([]*plextrac.InventoryAsset{
+		{Asset: "ws01.corp.local", OS: "Windows 11"},
+		{Asset: "10.0.0.7", Type: "Workstation"},
+		{Asset: "dc01.corp.local", Type: "Server"},
+	}, false)
+	if err != nil {
+		t.Fatalf("ImportInventory() returned error: %v", err)
+	}
+
+	if len(changes.Created) != 0 || len(changes.Updated) != 1 || len(changes.Unchanged) != 1 {
+		t.Fatalf("expected 1 updated and 1 unchanged, got %#v", changes)
+	}
+
+	puts := m.requestsFor("PUT /api/v1/client/123/asset/asset-ws01")
+	if len(puts) != 1 {
+		t.Fatalf("expected ws01 to be updated once, got %d updates", len(puts))
+	}
+
+	if puts[0]["asset"] != "WS01.Corp.Local." || puts[0]["hostname"] != "WS01.Corp.Local." {
+		t.Errorf("expected the name of ws01 to be left alone, got %#v", puts[0])
+	}
+
+	if puts[0]["type"] != "Workstation" {
+		t.Errorf("expected ws01 to get both updates, got %#v", puts[0])
+	}
+
+	if ips, _ := puts[0]["knownIps"].([]any); len(ips) != 1 {
+		t.Errorf("expected ws01 to keep one address, got %#v", puts[0]["knownIps"])
+	}
+
+	if len(m.requestsFor("PUT /api/v1/client/123/asset/asset-dc01")) != 0 {
+		t.Error("expected dc01 to be left alone")
+	}
+}
+
+func TestClient_AddAssets(t *testing.T) {
+	t.Parallel()
+
+	routes := assetRoutes()
+	routes["PUT /api/v1/client/123/asset/asset-ws01"] = func(t *testing.T, body map[string]any) any {
+		t.Helper()
+
+		return map[string]any{"status": "success"}
+	}
+
+	m := newMockAPI(routes)
+	c := mockClient(t, m)
+
+	changes, _, err := c.AddAssets([]*plextrac.InventoryAsset{
+		{Asset: "dc01.corp.local"},
+		{Asset: "WS01.corp.local", OS: "Windows 11"},
+		{Asset: "web01.corp.local", IPs: []string{"10.0.0.80"}},
+		{Asset: "10.0.0.80", OS: "Linux"},
+	}, false)
+	if err != nil {
+		t.Fatalf("AddAssets() returned error: %v", err)
+	}
+
+	if len(changes.Created) != 1 || len(changes.Updated) != 1 || len(changes.Unchanged) != 1 {
+		t.Fatalf("expected 1 created, 1 updated and 1 unchanged, got %#v", changes)
+	}
+
+	if len(m.requestsFor("GET /api/v1/client/123/assets")) != 0 {
+		t.Error("expected the inventory to be compared, not fetched")
+	}
+
+	compares := m.requestsFor("POST /api/v2/client/123/assets/compare")
+	if len(compares) != 1 {
+		t.Fatalf("expected one compare, got %d", len(compares))
+	}
+
+	if pasted, _ := compares[0]["pastedAssets"].([]any); len(pasted) != 3 {
+		t.Errorf("expected the 3 deduplicated assets to be compared, got %#v", pasted)
+	}
+
+	creates := m.requestsFor("POST /api/v2/client/123/bulk/assets")
+	if len(creates) != 1 {
+		t.Fatalf("expected one bulk create, got %d", len(creates))
+	}
+
+	requested, _ := creates[0]["assets"].([]any)
+	web01, _ := requested[0].(map[string]any)
+
+	if os, _ := web01["operating_system"].([]any); web01["asset"] != "web01.corp.local" || len(os) != 1 || os[0] != "Linux" {
+		t.Errorf("expected web01 to be created with its details, got %#v", web01)
+	}
+
+	if changes.Created[0].ID != "asset-new-web01.corp.local" {
+		t.Errorf("expected the new asset to get its id, got %q", changes.Created[0].ID)
+	}
+
+	puts := m.requestsFor("PUT /api/v1/client/123/asset/asset-ws01")
+	if len(puts) != 1 {
+		t.Fatalf("expected ws01 to be updated, got %d updates", len(puts))
+	}
+
+	if puts[0]["asset"] != "ws01.corp.local" {
+		t.Errorf("expected ws01 to be updated, got %#v", puts[0])
+	}
+}
+
+func TestClient_ImportInventory_dry_run(t *testing.T) {
+	t.Parallel()
+
+	m := newMockAPI(inventoryRoutes())
+	c := mockClient(t, m)
+
+	changes, _, err := c.ImportInventory([]*plextrac.InventoryAsset{
+		{Asset: "web01.corp.local"},
+		{Asset: "ws01.corp.local", Type: "Workstation"},
+	}, true)
+	if err != nil {
+		t.Fatalf("ImportInventory() returned error: %v", err)
+	}
+
+	if len(changes.Created) != 1 || len(changes.Updated) != 1 {
+		t.Errorf("expected 1 created and 1 updated, got %#v", changes)
+	}
+
+	if len(m.requestsFor("POST /api/v2/client/123/bulk/assets")) != 0 ||
+		len(m.requestsFor("PUT /api/v1/client/123/asset/asset-ws01")) != 0 {
+		t.Error("expected nothing to change on a dry run")
+	}
+}
+
+func TestClient_InventoryFindings(t *testing.T) {
+	t.Parallel()
+
+	raw := testFindingRaw()
+	raw["affected_assets"] = map[string]any{
+		"asset-dc01": map[string]any{"id": "asset-dc01", "asset": "dc01.corp.local"},
+	}
+
+	routes := inventoryRoutes()
+	for k, v := range findingRoutes(raw) {
+		routes[k] = v
+	}
+
+	c := mockClient(t, newMockAPI(routes))
+
+	inventory, _, err := c.Inventory()
+	if err != nil {
+		t.Fatalf("Inventory() returned error: %v", err)
+	}
+
+	_, err = c.InventoryFindings(inventory)
+	if err != nil {
+		t.Fatalf("InventoryFindings() returned error: %v", err)
+	}
+
+	if !slices.Equal(inventory[0].Findings, []string{"Test Report / Test Finding"}) {
+		t.Errorf("expected dc01 to be referenced by the finding, got %#v", inventory[0].Findings)
+	}
+
+	if len(inventory[1].Findings) != 0 {
+		t.Errorf("expected ws01 not to be referenced, got %#v", inventory[1].Findings)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"mime/multipart"
 	"net/http"
 	"strings"
 	"sync"
@@ -428,6 +429,111 @@ func (ua *UserAgent) apiCall(method, path string, body any, response any) (strin
 	return string(bodyResp), err
 }
 
+// apiUpload posts a file as multipart form data, the way the web app
+// uploads images.
+func (ua *UserAgent) apiUpload(path string, filename string, data []byte, response any) (string, error) {
+	_, err := ua.checkExpired()
+	if err != nil {
+		return "", err
+	}
+
+	ua.authTokenMutex.Lock()
+	defer ua.authTokenMutex.Unlock()
+
+	fullpath := "https://" + ua.tenantURL + "/api/" + path
+	slog.Debug("Uploading to API",
+		"url", fullpath,
+		"filename", filename,
+	)
+
+	var reqBody bytes.Buffer
+
+	mw := multipart.NewWriter(&reqBody)
+
+	part, err := mw.CreateFormFile("file", filename)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = part.Write(data)
+	if err != nil {
+		return "", err
+	}
+
+	err = mw.Close()
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, fullpath, &reqBody)
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+ua.authToken)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+
+	resp, err := ua.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+
+	defer must(resp.Body.Close)
+
+	bodyResp, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return string(bodyResp), fmt.Errorf("upload failed with status %s", resp.Status)
+	}
+
+	if response != nil {
+		err = json.Unmarshal(bodyResp, response)
+	}
+
+	return string(bodyResp), err
+}
+
+// apiDownload gets the raw content of a URL, such as an uploaded image. Only
+// URLs on the instance get the auth token.
+func (ua *UserAgent) apiDownload(fullpath string) ([]byte, error) {
+	_, err := ua.checkExpired()
+	if err != nil {
+		return nil, err
+	}
+
+	ua.authTokenMutex.Lock()
+	defer ua.authTokenMutex.Unlock()
+
+	slog.Debug("Downloading from API",
+		"url", fullpath,
+	)
+
+	req, err := http.NewRequest(http.MethodGet, fullpath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.URL.Host == ua.tenantURL {
+		req.Header.Set("Authorization", "Bearer "+ua.authToken)
+	}
+
+	resp, err := ua.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer must(resp.Body.Close)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("download failed with status %s", resp.Status)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
 func must(f func() error) {
 	err := f()
 	if err != nil {
@@ -324,6 +324,86 @@ func (r *Report) Sections() ([]Section, []error, error) {
 	return r.sections, warnings, nil
 }
 
+func (r *Report) SectionByPartial(partial string) (*Section, []error, error) {
+	sections, warnings, err := r.Sections()
+	if err != nil {
+		return nil, warnings, err
+	}
+
+	var match *Section
+
+	matches := 0
+
+	for i, s := range sections {
+		if s.Title == partial {
+			return &r.sections[i], warnings, nil
+		}
+
+		if strings.Contains(strings.ToLower(s.Title), strings.ToLower(partial)) {
+			match = &r.sections[i]
+			matches++
+		}
+	}
+
+	if matches == 0 {
+		return nil, warnings, errors.New("section not found")
+	}
+
+	if matches > 1 {
+		return nil, warnings, errors.New("multiple sections match")
+	}
+
+	return match, warnings, nil
+}
+
+func (r *Report) SetSectionContent(id string, content string) ([]error, error) {
+	warnings, err := r.EnsureFull()
+	if err != nil {
+		return warnings, err
+	}
+
+	execSummary, ok := r.raw["exec_summary"].(map[string]any)
+	if !ok {
+		return warnings, errors.New("unable to coerce exec_summary into map[string]interface{}")
+	}
+
+	customFields, ok := execSummary["custom_fields"].([]any)
+	if !ok {
+		return warnings, errors.New("unable to coerce custom_fields into []interface{}")
+	}
+
+	found := false
+
+	for _, cf := range customFields {
+		field, ok := cf.(map[string]any)
+		if !ok {
+			return warnings, errors.New("unable to coerce custom field into map[string]interface{}")
+		}
+
+		if field["id"] == id {
+			field["text"] = content
+			found = true
+
+			break
+		}
+	}
+
+	if !found {
+		return warnings, fmt.Errorf("section %s not found", id)
+	}
+
+	for i := range r.sections {
+		if r.sections[i].ID == id {
+			r.sections[i].Content = content
+		}
+	}
+
+	warnings2, err := r.update()
+	warnings = append(warnings, warnings2...)
+
+	return warnings, err
+}
+
 func (r *Report) GetTemplateID() (string, []error, error) {
 	warnings, err := r.EnsureFull()
 	if err != nil {
@@ -357,7 +437,6 @@ func (r *Report) RemoveTags(tags []string) ([]error, error) {
 	r.tags = slices.DeleteFunc(r.tags, func(t string) bool {
 		return slices.Contains(tags, t)
 	})
-	fmt.Printf("tags: %#v\n", r.tags)
 	r.raw["tags"] = r.tags
 
 	return r.update()
@@ -376,10 +455,8 @@ func (r *Report) SetTags(tags []string) ([]error, error) {
 func (r *Report) update() ([]error, error) {
 	path := fmt.Sprintf("v1/client/%d/report/%d", r.c.ID, r.ID)
 
-	body, err := r.ua.apiCall(http.MethodPut, path, r.raw, nil)
+	_, err := r.ua.apiCall(http.MethodPut, path, r.raw, nil)
 	if err != nil {
-		fmt.Printf("body: %s\n", body)
-
 		return nil, fmt.Errorf("error updating report: %w", err)
 	}
 
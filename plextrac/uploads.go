@@ -0,0 +1,194 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package plextrac
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/brimstone/plextraccli/richtext"
+)
+
+// ImageExtensions are the kinds of images PlexTrac takes for evidence.
+var ImageExtensions = []string{".png", ".jpg", ".jpeg", ".gif"}
+
+// EvidenceImage is a screenshot to add to a finding's evidence.
+type EvidenceImage struct {
+	Filename string
+	Data     []byte
+	Caption  string
+}
+
+// UploadImage uploads an image for use in the client's rich text fields, and
+// returns the src to reference it by.
+func (c *Client) UploadImage(filename string, data []byte) (string, error) {
+	var uploadResponse struct {
+		Status string `json:"status"`
+		Src    string `json:"src"`
+		URL    string `json:"url"`
+	}
+
+	ext := strings.ToLower(filepath.Ext(filename))
+	if !slices.Contains(ImageExtensions, ext) {
+		return "", fmt.Errorf("%s is not an image, must be one of: %s", filename, strings.Join(ImageExtensions, ", "))
+	}
+
+	contentType := http.DetectContentType(data)
+	if !strings.HasPrefix(contentType, "image/") {
+		return "", fmt.Errorf("%s looks like %s, not an image", filename, contentType)
+	}
+
+	path := fmt.Sprintf("v1/client/%d/upload", c.ID)
+
+	body, err := c.ua.apiUpload(path, filepath.Base(filename), data, &uploadResponse)
+	if err != nil {
+		return "", fmt.Errorf("error uploading %s: %w: %s", filename, err, body)
+	}
+
+	// Depending on the version, this is the src or the url
+	src := uploadResponse.Src
+	if src == "" {
+		src = uploadResponse.URL
+	}
+
+	if src == "" {
+		return "", fmt.Errorf("error uploading %s: %s", filename, body)
+	}
+
+	return src, nil
+}
+
+// AddEvidenceImages uploads the images and appends them to the finding's
+// evidence as figures, in order, with their captions.
+func (f *Finding) AddEvidenceImages(images []EvidenceImage) ([]error, error) {
+	warnings, err := f.EnsureFull()
+	if err != nil {
+		return warnings, err
+	}
+
+	evidence := f.Evidence
+
+	for _, image := range images {
+		src, err := f.r.c.UploadImage(image.Filename, image.Data)
+		if err != nil {
+			return warnings, err
+		}
+
+		evidence += richtext.Figure(src, image.Caption)
+	}
+
+	warnings2, err := f.SetEvidence(evidence)
+	warnings = append(warnings, warnings2...)
+
+	return warnings, err
+}
+
+// DownloadImage gets an image referenced by rich text. The src can be a path
+// on the instance, as PlexTrac writes them, a full URL, or a data URI.
+func (ua *UserAgent) DownloadImage(src string) ([]byte, error) {
+	if strings.HasPrefix(src, "data:") {
+		meta, data, ok := strings.Cut(strings.TrimPrefix(src, "data:"), ",")
+		if !ok {
+			return nil, errors.New("bad data uri")
+		}
+
+		if strings.HasSuffix(meta, ";base64") {
+			return base64.StdEncoding.DecodeString(data)
+		}
+
+		decoded, err := url.PathUnescape(data)
+
+		return []byte(decoded), err
+	}
+
+	u, err := url.Parse(src)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Host == "" {
+		u, err = url.Parse("https://" + ua.tenantURL + "/" + strings.TrimPrefix(src, "/"))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	data, err := ua.apiDownload(u.String())
+	if err != nil {
+		return nil, fmt.Errorf("unable to download %s: %w", src, err)
+	}
+
+	return data, nil
+}
+
+// imageExtension picks the extension to save an image with, by what it looks
+// like and failing that, by its src.
+func imageExtension(src string, data []byte) string {
+	switch http.DetectContentType(data) {
+	case "image/png":
+		return ".png"
+	case "image/jpeg":
+		return ".jpg"
+	case "image/gif":
+		return ".gif"
+	case "image/webp":
+		return ".webp"
+	case "image/bmp":
+		return ".bmp"
+	}
+
+	if u, err := url.Parse(src); err == nil {
+		if ext := strings.ToLower(path.Ext(u.Path)); ext != "" && len(ext) <= 5 {
+			return ext
+		}
+	}
+
+	return ".bin"
+}
+
+// PullImages downloads the images of the rich text into dir, named after
+// name and numbered in the order they appear, eg: name-01.png. It returns
+// the rich text pointing at the downloaded files, by their file names, and
+// the paths it wrote.
+func (ua *UserAgent) PullImages(content string, dir string, name string) (string, []string, error) {
+	srcs := richtext.ImageSources(content)
+	if len(srcs) == 0 {
+		return content, nil, nil
+	}
+
+	err := os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return content, nil, err
+	}
+
+	local := make(map[string]string)
+
+	var files []string
+
+	for i, src := range srcs {
+		data, err := ua.DownloadImage(src)
+		if err != nil {
+			return content, files, err
+		}
+
+		filename := fmt.Sprintf("%s-%02d%s", name, i+1, imageExtension(src, data))
+
+		err = os.WriteFile(filepath.Join(dir, filename), data, 0o644) //nolint:gosec
+		if err != nil {
+			return content, files, err
+		}
+
+		local[src] = filename
+		files = append(files, filepath.Join(dir, filename))
+	}
+
+	return richtext.ReplaceImageSources(content, local), files, nil
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package plextrac_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/brimstone/plextraccli/plextrac"
+)
+
+// png is the start of a PNG file, enough to be sniffed as one.
+var png = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func TestFinding_AddEvidenceImages(t *testing.T) {
+	t.Parallel()
+
+	routes := findingRoutes(testFindingRaw())
+	uploads := 0
+	routes["POST /api/v1/client/123/upload"] = func(t *testing.T, body map[string]any) any {
+		t.Helper()
+
+		uploads++
+
+		return map[string]any{"status": "success", "src": "/api/v1/uploads/" + []string{"", "one", "two"}[uploads] + ".png"}
+	}
+
+	m := newMockAPI(routes)
+	f := mockFinding(t, m)
+
+	_, err := f.AddEvidenceImages([]plextrac.EvidenceImage{
+		{Filename: "01-responder.png", Data: png, Caption: "Responder capturing hashes."},
+		{Filename: "02-cracked.png", Data: png, Caption: "Cracked hashes"},
+	})
+	if err != nil {
+		t.Fatalf("AddEvidenceImages() returned error: %v", err)
+	}
+
+	puts := m.requestsFor("PUT /api/v1/client/123/report/456/flaw/789")
+	if len(puts) != 1 {
+		t.Fatalf("expected one update for all the images, got %d", len(puts))
+	}
+
+	fields, _ := puts[0]["fields"].(map[string]any)
+	evidence, _ := fields["evidence"].(map[string]any)
+	value, _ := evidence["value"].(string)
+
+	expected := `<p>evidence</p>` +
+		`<figure><img src="/api/v1/uploads/one.png" alt="Responder capturing hashes"><figcaption>Responder capturing hashes</figcaption></figure>` +
+		`<figure><img src="/api/v1/uploads/two.png" alt="Cracked hashes"><figcaption>Cracked hashes</figcaption></figure>`
+	if value != expected {
+		t.Errorf("evidence = %q, want %q", value, expected)
+	}
+}
+
+func TestFinding_AddEvidenceImages_rejects_non_images(t *testing.T) {
+	t.Parallel()
+
+	m := newMockAPI(findingRoutes(testFindingRaw()))
+	f := mockFinding(t, m)
+
+	for _, image := range []plextrac.EvidenceImage{
+		{Filename: "notes.txt", Data: []byte("hello")},
+		{Filename: "fake.png", Data: []byte("hello")},
+	} {
+		_, err := f.AddEvidenceImages([]plextrac.EvidenceImage{image})
+		if err == nil || !strings.Contains(err.Error(), image.Filename) {
+			t.Errorf("expected %s to be rejected, got %v", image.Filename, err)
+		}
+	}
+
+	if len(m.requestsFor("PUT /api/v1/client/123/report/456/flaw/789")) != 0 {
+		t.Error("expected the finding not to be updated")
+	}
+}
+
+func TestUserAgent_PullImages(t *testing.T) {
+	t.Parallel()
+
+	m := newMockAPI(map[string]mockRoute{
+		"GET /api/v1/uploads/one.png": func(t *testing.T, body map[string]any) any {
+			t.Helper()
+
+			return png
+		},
+	})
+	ua := mockUserAgent(t, m)
+	dir := t.TempDir()
+
+	content := `<p>Hashes</p><figure><img src="/api/v1/uploads/one.png" alt="a"><figcaption>a</figcaption></figure>` +
+		`<img src="data:image/gif;base64,R0lGODlhAQABAAAAACw=">` +
+		`<img src="/api/v1/uploads/one.png">`
+
+	got, files, err := ua.PullImages(content, dir, "smb-signing")
+	if err != nil {
+		t.Fatalf("PullImages() returned error: %v", err)
+	}
+
+	expected := `<p>Hashes</p><figure><img src="smb-signing-01.png" alt="a"><figcaption>a</figcaption></figure>` +
+		`<img src="smb-signing-02.gif">` +
+		`<img src="smb-signing-01.png">`
+	if got != expected {
+		t.Errorf("PullImages() = %q, want %q", got, expected)
+	}
+
+	expectedFiles := []string{filepath.Join(dir, "smb-signing-01.png"), filepath.Join(dir, "smb-signing-02.gif")}
+	if !slices.Equal(files, expectedFiles) {
+		t.Errorf("files = %v, want %v", files, expectedFiles)
+	}
+
+	data, err := os.ReadFile(files[0])
+	if err != nil || !bytes.Equal(data, png) {
+		t.Errorf("expected the downloaded image to be written, got %q, %v", data, err)
+	}
+
+	if len(m.requestsFor("GET /api/v1/uploads/one.png")) != 1 {
+		t.Error("expected each image to be downloaded once")
+	}
+}
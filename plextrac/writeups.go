@@ -2,7 +2,13 @@
 
 package plextrac
 
-import "fmt"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+)
 
 type Writeup struct {
 	CreatedAt   int64  `json:"createdAt"`
@@ -89,6 +95,78 @@ type Writeup struct {
 	Title               string   `json:"title"`
 	UpdatedAt           int64    `json:"updatedAt"`
 	WriteupAbbreviation string   `json:"writeupAbbreviation"`
+
+	// fields are the custom fields as PlexTrac gave them, including the
+	// ones Fields doesn't know about
+	fields map[string]any
+}
+
+// UnmarshalJSON keeps the fields of the writeup as PlexTrac gave them, as
+// well as in Fields.
+func (w *Writeup) UnmarshalJSON(data []byte) error {
+	type writeup Writeup
+
+	err := json.Unmarshal(data, (*writeup)(w))
+	if err != nil {
+		return err
+	}
+
+	var raw struct {
+		Fields map[string]any `json:"fields"`
+	}
+
+	err = json.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+
+	w.fields = raw.Fields
+
+	return nil
+}
+
+// findingFields are the custom fields for a finding created from the
+// writeup: the ones PlexTrac gave, or failing that, the ones set in Fields.
+func (w *Writeup) findingFields() (map[string]any, error) {
+	if w.fields != nil {
+		return maps.Clone(w.fields), nil
+	}
+
+	data, err := json.Marshal(w.Fields)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal writeup fields: %w", err)
+	}
+
+	var fields map[string]any
+
+	err = json.Unmarshal(data, &fields)
+	if err != nil {
+		return nil, fmt.Errorf("unable to unmarshal writeup fields: %w", err)
+	}
+
+	// Fields has every field it knows about, set or not
+	maps.DeleteFunc(fields, func(_ string, v any) bool { return unset(v) })
+
+	return fields, nil
+}
+
+// unset reports whether a value decoded from JSON is empty, or made only of
+// empty values.
+func unset(v any) bool {
+	switch v := v.(type) {
+	case map[string]any:
+		for _, value := range v {
+			if !unset(value) {
+				return false
+			}
+		}
+
+		return true
+	case []any:
+		return len(v) == 0
+	}
+
+	return v == nil || v == "" || v == float64(0) || v == false
 }
 
 type writeupRepositoriesResponse struct {
@@ -151,3 +229,35 @@ func (ua *UserAgent) Writeups() ([]*Writeup, error) {
 
 	return writeups, err
 }
+
+func (ua *UserAgent) WriteupByTitle(title string) (*Writeup, error) {
+	writeups, err := ua.Writeups()
+	if err != nil {
+		return nil, err
+	}
+
+	var match *Writeup
+
+	matches := 0
+
+	for _, w := range writeups {
+		if w.Title == title {
+			return w, nil
+		}
+
+		if strings.Contains(strings.ToLower(w.Title), strings.ToLower(title)) {
+			match = w
+			matches++
+		}
+	}
+
+	if matches == 0 {
+		return nil, errors.New("writeup not found")
+	}
+
+	if matches > 1 {
+		return nil, errors.New("multiple writeups match")
+	}
+
+	return match, nil
+}
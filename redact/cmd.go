@@ -0,0 +1,255 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package redact
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/brimstone/plextraccli/types"
+	"github.com/brimstone/plextraccli/utils"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var defaultCols = []string{"location", "detector", "secret"}
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "redact",
+		Short: "Find secrets in evidence and narratives, and mask them",
+		Long: `Find secrets in the evidence of findings and the narratives of a report,
+such as NTLM hashes, Kerberos tickets, cleartext passwords, AWS keys,
+private keys and JWTs.
+
+With --fix, the secrets are replaced with ` + Mask + ` in PlexTrac. The
+secrets in each narrative and finding are listed and masked once confirmed,
+or right away with --yes.
+
+Detectors are turned off, and more added, in the config:
+
+  redact:
+    disable: [jwt]
+    patterns:
+      - name: domain-password
+        pattern: 'Summer\d{4}!'`,
+		Args: cobra.NoArgs,
+		RunE: cmdRedact,
+	}
+	cmd.Flags().String("cols", strings.Join(defaultCols, ","), "Columns to show")
+	cmd.Flags().Bool("fix", false, "Mask the secrets in PlexTrac")
+	cmd.Flags().BoolP("yes", "y", false, "Mask secrets without asking")
+
+	detectorsCmd := &cobra.Command{
+		Use:   "detectors",
+		Short: "List the detectors in use",
+		Args:  cobra.NoArgs,
+		RunE:  cmdRedactDetectors,
+	}
+	cmd.AddCommand(detectorsCmd)
+
+	return cmd
+}
+
+// Configured returns a Redactor set up by the redact section of the config.
+func Configured() (*Redactor, error) {
+	var cfg types.RedactConfig
+
+	err := viper.UnmarshalKey("redact", &cfg)
+	if err != nil {
+		return nil, fmt.Errorf("error reading redact config: %w", err)
+	}
+
+	return New(cfg)
+}
+
+func cmdRedactDetectors(cmd *cobra.Command, args []string) error {
+	r, err := Configured()
+	if err != nil {
+		return err
+	}
+
+	var rows [][]string
+
+	for _, d := range r.Detectors() {
+		rows = append(rows, []string{
+			d.Name,
+			d.Description,
+		})
+	}
+
+	utils.ShowTable(
+		[]string{
+			"Name",
+			"Description",
+		},
+		rows,
+		[]string{"name", "description"},
+	)
+
+	return nil
+}
+
+func cmdRedact(cmd *cobra.Command, args []string) error {
+	showCols := utils.AggregateCols(defaultCols, cmd.Flag("cols").Value.String())
+
+	fix, err := cmd.Flags().GetBool("fix")
+	if err != nil {
+		return err
+	}
+
+	yes, err := cmd.Flags().GetBool("yes")
+	if err != nil {
+		return err
+	}
+
+	redactor, err := Configured()
+	if err != nil {
+		return err
+	}
+
+	_, r, warnings, err := utils.GetReport()
+	if err != nil {
+		return err
+	}
+
+	var rows [][]string
+
+	addRows := func(location string, matches []Match) {
+		for _, m := range matches {
+			rows = append(rows, []string{
+				location,
+				m.Detector,
+				m.Preview(),
+			})
+		}
+	}
+
+	sections, warnings2, err := r.Sections()
+	warnings = append(warnings, warnings2...)
+
+	if err != nil {
+		return err
+	}
+
+	fixed := 0
+
+	for _, s := range sections {
+		content, matches := redactor.Redact(s.Content)
+		if len(matches) == 0 {
+			continue
+		}
+
+		addRows("narrative: "+s.Title, matches)
+
+		if fix {
+			ok, err := confirmRedact(fmt.Sprintf("narrative %q", s.Title), matches, yes)
+			if err != nil {
+				return err
+			}
+
+			if !ok {
+				continue
+			}
+
+			warnings2, err := r.SetSectionContent(s.ID, content)
+			warnings = append(warnings, warnings2...)
+
+			if err != nil {
+				return fmt.Errorf("unable to redact %s: %w", s.Title, err)
+			}
+
+			fixed += len(matches)
+		}
+	}
+
+	findings, warnings2, err := r.Findings()
+	warnings = append(warnings, warnings2...)
+
+	if err != nil {
+		return err
+	}
+
+	for _, f := range findings {
+		warnings2, err := f.EnsureFull()
+		warnings = append(warnings, warnings2...)
+
+		if err != nil {
+			return err
+		}
+
+		evidence, matches := redactor.Redact(f.Evidence)
+		if len(matches) == 0 {
+			continue
+		}
+
+		addRows("finding: "+f.Name, matches)
+
+		if fix {
+			ok, err := confirmRedact(fmt.Sprintf("finding %q", f.Name), matches, yes)
+			if err != nil {
+				return err
+			}
+
+			if !ok {
+				continue
+			}
+
+			warnings2, err := f.SetEvidence(evidence)
+			warnings = append(warnings, warnings2...)
+
+			if err != nil {
+				return fmt.Errorf("unable to redact %s: %w", f.Name, err)
+			}
+
+			fixed += len(matches)
+		}
+	}
+
+	for _, warning := range warnings {
+		slog.Warn("Warning while redacting",
+			"warning", warning,
+		)
+	}
+
+	if len(rows) == 0 {
+		fmt.Printf("No secrets found\n")
+
+		return nil
+	}
+
+	utils.ShowTable(
+		[]string{
+			"Location",
+			"Detector",
+			"Secret",
+		},
+		rows,
+		showCols,
+	)
+
+	if fix {
+		fmt.Printf("Redacted %d secrets\n", fixed)
+	}
+
+	return nil
+}
+
+// confirmRedact lists the secrets found in name, and asks whether to mask
+// them.
+func confirmRedact(name string, matches []Match, yes bool) (bool, error) {
+	fmt.Fprintf(os.Stderr, "Masking %d secrets in %s:\n", len(matches), name)
+
+	for _, m := range matches {
+		fmt.Fprintf(os.Stderr, "  %s: %s\n", m.Detector, m.Preview())
+	}
+
+	if yes {
+		return true, nil
+	}
+
+	return utils.Confirm("Mask the secrets in " + name + "?")
+}
@@ -0,0 +1,201 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+// Package redact finds secrets, such as password hashes and keys, in
+// evidence and narratives, and masks them.
+//
+// Each kind of secret has a Detector, a regular expression where the secret
+// itself is the subexpression named "secret", or the whole match if there
+// isn't one, so that the context around it stays readable once it's masked.
+package redact
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/brimstone/plextraccli/types"
+)
+
+// Mask replaces each secret.
+const Mask = "[REDACTED]"
+
+// Detector finds one kind of secret.
+type Detector struct {
+	Name        string
+	Description string
+	Pattern     *regexp.Regexp
+}
+
+// Detectors are the built in detectors, all enabled unless disabled in the
+// config.
+var Detectors = []Detector{
+	// Before ntlm, which also matches the end of these
+	{
+		Name:        "netntlm",
+		Description: "NetNTLMv1 and v2 hashes, as captured by Responder",
+		Pattern:     regexp.MustCompile(`(?i)[\w.$-]+::[\w.-]*:[0-9a-f]{16}:(?P<secret>[0-9a-f]{32,48}:[0-9a-f]+)`),
+	},
+	{
+		Name:        "ntlm",
+		Description: "LM and NT hashes, as dumped by secretsdump",
+		Pattern:     regexp.MustCompile(`(?i)\b(?P<secret>[0-9a-f]{32}:[0-9a-f]{32})\b`),
+	},
+	{
+		Name:        "kerberos",
+		Description: "Kerberos tickets, base64 encoded or as hashcat hashes",
+		Pattern:     regexp.MustCompile(`\bdoI[A-Za-z0-9+/]{60,}={0,2}|\$krb5(?:tgs|asrep|pa)\$[^\s<]+`),
+	},
+	{
+		Name:        "password",
+		Description: "Cleartext passwords after password: or password=",
+		Pattern:     regexp.MustCompile(`(?i)\b(?:password|passwd|pwd)\s*[:=]\s*(?P<secret>[^\s<]+)`),
+	},
+	{
+		Name:        "aws",
+		Description: "AWS access key IDs and secret access keys",
+		Pattern:     regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b|(?i)aws_secret_access_key\s*[:=]\s*(?P<secret>[A-Za-z0-9/+=]{40})`),
+	},
+	{
+		Name:        "private-key",
+		Description: "PEM private keys",
+		Pattern:     regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----(?P<secret>[\s\S]+?)-----END [A-Z ]*PRIVATE KEY-----`),
+	},
+	{
+		Name:        "jwt",
+		Description: "JSON web tokens",
+		Pattern:     regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{8,}\.eyJ[A-Za-z0-9_-]{8,}\.(?P<secret>[A-Za-z0-9_-]{8,})`),
+	},
+}
+
+// Match is a secret found by a detector.
+type Match struct {
+	Detector string
+	// Start and End are the offsets of the secret in the text
+	Start int
+	End   int
+	// Secret is what would be masked
+	Secret string
+}
+
+// Preview shows enough of the secret to find it again, without repeating
+// all of it.
+func (m Match) Preview() string {
+	secret := strings.Join(strings.Fields(m.Secret), " ")
+	if len(secret) <= 8 {
+		return strings.Repeat("*", len(secret))
+	}
+
+	return secret[:4] + "…" + secret[len(secret)-2:]
+}
+
+// Redactor finds and masks secrets with a set of detectors.
+type Redactor struct {
+	detectors []Detector
+}
+
+// New returns a Redactor with the built in detectors that aren't disabled by
+// the config, followed by the config's own patterns.
+func New(cfg types.RedactConfig) (*Redactor, error) {
+	r := &Redactor{}
+
+	for _, name := range cfg.Disable {
+		if !slices.ContainsFunc(Detectors, func(d Detector) bool { return d.Name == name }) {
+			return nil, fmt.Errorf("unknown detector %q to disable", name)
+		}
+	}
+
+	for _, d := range Detectors {
+		if !slices.Contains(cfg.Disable, d.Name) {
+			r.detectors = append(r.detectors, d)
+		}
+	}
+
+	for _, p := range cfg.Patterns {
+		re, err := regexp.Compile(p.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("bad pattern for detector %q: %w", p.Name, err)
+		}
+
+		r.detectors = append(r.detectors, Detector{
+			Name:        p.Name,
+			Description: p.Description,
+			Pattern:     re,
+		})
+	}
+
+	return r, nil
+}
+
+// Detectors returns the detectors the Redactor uses.
+func (r *Redactor) Detectors() []Detector {
+	return r.detectors
+}
+
+// Find returns the secrets in the text, in the order they appear. Secrets
+// that are already masked aren't found again.
+func (r *Redactor) Find(text string) []Match {
+	var matches []Match
+
+	for _, d := range r.detectors {
+		secret := d.Pattern.SubexpIndex("secret")
+
+		for _, loc := range d.Pattern.FindAllStringSubmatchIndex(text, -1) {
+			start, end := loc[0], loc[1]
+			if secret > 0 && loc[2*secret] >= 0 {
+				start, end = loc[2*secret], loc[2*secret+1]
+			}
+
+			if start == end || text[start:end] == Mask {
+				continue
+			}
+
+			matches = append(matches, Match{
+				Detector: d.Name,
+				Start:    start,
+				End:      end,
+				Secret:   text[start:end],
+			})
+		}
+	}
+
+	slices.SortStableFunc(matches, func(a, b Match) int {
+		return a.Start - b.Start
+	})
+
+	// The same secret found by more than one detector is reported by the
+	// first of them
+	return slices.CompactFunc(matches, func(a, b Match) bool {
+		return a.Start == b.Start && a.End == b.End
+	})
+}
+
+// Redact masks the secrets in the text, returning the masked text and what
+// was masked. Overlapping secrets are masked once.
+func (r *Redactor) Redact(text string) (string, []Match) {
+	matches := r.Find(text)
+	if len(matches) == 0 {
+		return text, nil
+	}
+
+	var b strings.Builder
+
+	last := 0
+
+	for _, m := range matches {
+		if m.Start < last {
+			// Already masked as part of an earlier secret
+			last = max(last, m.End)
+
+			continue
+		}
+
+		b.WriteString(text[last:m.Start])
+		b.WriteString(Mask)
+		last = m.End
+	}
+
+	b.WriteString(text[last:])
+
+	return b.String(), matches
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package richtext
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var blankLines = regexp.MustCompile(`\n[ \t]*\n\s*`)
+
+// FromText turns plain text, such as a scanner's description of an issue,
+// into rich text, one paragraph per block of lines.
+func FromText(text string) string {
+	text = strings.TrimSpace(strings.ReplaceAll(text, "\r\n", "\n"))
+	if text == "" {
+		return ""
+	}
+
+	var b strings.Builder
+
+	for _, p := range blankLines.Split(text, -1) {
+		b.WriteString("<p>")
+		b.WriteString(strings.ReplaceAll(html.EscapeString(strings.TrimSpace(p)), "\n", "<br />"))
+		b.WriteString("</p>")
+	}
+
+	return b.String()
+}
+
+// LinkList turns URLs into a list of links.
+func LinkList(urls []string) string {
+	var b strings.Builder
+
+	for _, u := range urls {
+		u = strings.TrimSpace(u)
+		if u == "" {
+			continue
+		}
+
+		b.WriteString(`<li><a href="`)
+		b.WriteString(html.EscapeString(u))
+		b.WriteString(`">`)
+		b.WriteString(html.EscapeString(u))
+		b.WriteString("</a></li>")
+	}
+
+	if b.Len() == 0 {
+		return ""
+	}
+
+	return "<ul>" + b.String() + "</ul>"
+}
+
+// CodeBlock turns text, such as an HTTP request, into a code block, the
+// same way ToHTML renders a fenced block.
+func CodeBlock(text string) string {
+	text = strings.TrimRight(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+
+	return "<pre><code>" + html.EscapeString(text) + "</code></pre>"
+}
+
+// Figure turns an image and its caption into a figure, the way PlexTrac's
+// editor makes them. Captions don't end with a period.
+func Figure(src string, caption string) string {
+	caption = strings.TrimRight(strings.TrimSpace(caption), ".")
+
+	return `<figure><img src="` + html.EscapeString(src) + `" alt="` + html.EscapeString(caption) + `"><figcaption>` +
+		html.EscapeString(caption) + "</figcaption></figure>"
+}
+
+// CaptionedCodeBlock is a code block with its caption as a bold paragraph
+// above it, for command output in evidence. Without a caption it's just the
+// code block.
+func CaptionedCodeBlock(text string, caption string) string {
+	caption = strings.TrimRight(strings.TrimSpace(caption), ".")
+	if caption == "" {
+		return CodeBlock(text)
+	}
+
+	return "<p><strong>" + html.EscapeString(caption) + "</strong></p>" + CodeBlock(text)
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package richtext
+
+import (
+	"io"
+	"slices"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// ImageSources returns the src of each image in the rich text, once each, in
+// the order they first appear.
+func ImageSources(content string) []string {
+	var srcs []string
+
+	z := html.NewTokenizer(strings.NewReader(content))
+
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			return srcs
+		}
+
+		if tt != html.StartTagToken && tt != html.SelfClosingTagToken {
+			continue
+		}
+
+		t := z.Token()
+		if t.Data != "img" {
+			continue
+		}
+
+		for _, a := range t.Attr {
+			if a.Key == "src" && a.Val != "" && !slices.Contains(srcs, a.Val) {
+				srcs = append(srcs, a.Val)
+			}
+		}
+	}
+}
+
+// ReplaceImageSources points the images of the rich text at new sources,
+// keyed by their old ones. Everything else is left as it was.
+func ReplaceImageSources(content string, srcs map[string]string) string {
+	var b strings.Builder
+
+	z := html.NewTokenizer(strings.NewReader(content))
+
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			if z.Err() != io.EOF {
+				// Give up on what can't be tokenized rather than lose it
+				return content
+			}
+
+			return b.String()
+		}
+
+		raw := z.Raw()
+
+		if tt != html.StartTagToken && tt != html.SelfClosingTagToken {
+			b.Write(raw)
+
+			continue
+		}
+
+		// Token() unescapes, so copy raw before it's overwritten
+		raw = slices.Clone(raw)
+
+		t := z.Token()
+		if t.Data != "img" {
+			b.Write(raw)
+
+			continue
+		}
+
+		changed := false
+
+		for i, a := range t.Attr {
+			if src, ok := srcs[a.Val]; ok && a.Key == "src" {
+				t.Attr[i].Val = src
+				changed = true
+			}
+		}
+
+		if !changed {
+			b.Write(raw)
+
+			continue
+		}
+
+		b.WriteString(t.String())
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package richtext_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/brimstone/plextraccli/richtext"
+)
+
+const imagesContent = `<p>Responder &amp; friends</p>` +
+	`<figure><img src="/api/v1/uploads/a.png" alt="Hashes"><figcaption>Hashes</figcaption></figure>` +
+	`<p><img src="/api/v1/uploads/b.png"/></p>` +
+	`<img src="/api/v1/uploads/a.png">`
+
+func TestImageSources(t *testing.T) {
+	t.Parallel()
+
+	expected := []string{"/api/v1/uploads/a.png", "/api/v1/uploads/b.png"}
+
+	got := richtext.ImageSources(imagesContent)
+	if !slices.Equal(got, expected) {
+		t.Errorf("ImageSources() = %v, want %v", got, expected)
+	}
+
+	if got := richtext.ImageSources("<p>no images</p>"); len(got) != 0 {
+		t.Errorf("expected no images, got %v", got)
+	}
+}
+
+func TestReplaceImageSources(t *testing.T) {
+	t.Parallel()
+
+	expected := `<p>Responder &amp; friends</p>` +
+		`<figure><img src="a-01.png" alt="Hashes"><figcaption>Hashes</figcaption></figure>` +
+		`<p><img src="/api/v1/uploads/b.png"/></p>` +
+		`<img src="a-01.png">`
+
+	got := richtext.ReplaceImageSources(imagesContent, map[string]string{
+		"/api/v1/uploads/a.png": "a-01.png",
+	})
+	if got != expected {
+		t.Errorf("ReplaceImageSources() = %q, want %q", got, expected)
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+// Package richtext converts between PlexTrac's rich text HTML and Markdown
+// without losing content, so narratives, findings and writeups can be edited
+// as Markdown.
+package richtext
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/JohannesKaufmann/html-to-markdown/v2/converter"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/plugin/base"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/plugin/commonmark"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/plugin/strikethrough"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/plugin/table"
+	blackfriday "github.com/russross/blackfriday/v2"
+)
+
+const markdownExtensions = blackfriday.NoIntraEmphasis |
+	blackfriday.Tables |
+	blackfriday.FencedCode |
+	blackfriday.Autolink |
+	blackfriday.Strikethrough |
+	blackfriday.SpaceHeadings |
+	blackfriday.BackslashLineBreak
+
+const listEndComment = "<!--THE END-->"
+
+var (
+	// html-to-markdown leaves a blank line between a list item and its
+	// nested list, which blackfriday reads as a loose list.
+	nestedListGap = regexp.MustCompile(`\n[ \t]*\n([ \t]+(?:[-*+]|\d+\.) )`)
+	// blackfriday ends every code block with a newline.
+	codeBlockEnd = regexp.MustCompile(`\n</code></pre>`)
+)
+
+func newConverter() *converter.Converter {
+	conv := converter.NewConverter(
+		converter.WithPlugins(
+			base.NewBasePlugin(),
+			commonmark.NewCommonmarkPlugin(
+				commonmark.WithCodeBlockFence("```"),
+				commonmark.WithBulletListMarker("-"),
+			),
+			strikethrough.NewStrikethroughPlugin(),
+			table.NewTablePlugin(
+				table.WithCellPaddingBehavior(table.CellPaddingBehaviorMinimal),
+			),
+		),
+	)
+
+	// Markdown has no notion of a figure, so evidence blocks stay as HTML.
+	// Blackfriday passes block level HTML through untouched on the way back.
+	conv.Register.RendererFor("figure", converter.TagTypeBlock, base.RenderAsHTML, converter.PriorityEarly)
+
+	return conv
+}
+
+// ToMarkdown converts PlexTrac rich text HTML into Markdown.
+func ToMarkdown(content string) (string, error) {
+	md, err := newConverter().ConvertString(content)
+	if err != nil {
+		return "", err
+	}
+
+	return nestedListGap.ReplaceAllString(md, "\n$1"), nil
+}
+
+// ToHTML converts Markdown into PlexTrac rich text HTML.
+func ToHTML(content string) (string, error) {
+	out := string(blackfriday.Run([]byte(content), blackfriday.WithExtensions(markdownExtensions)))
+
+	// The list end comment only exists to keep adjacent lists apart in
+	// Markdown, so it shouldn't end up in the report.
+	out = strings.ReplaceAll(out, listEndComment+"\n", "")
+	out = strings.ReplaceAll(out, listEndComment, "")
+	out = codeBlockEnd.ReplaceAllString(out, "</code></pre>")
+
+	return strings.TrimSpace(out), nil
+}
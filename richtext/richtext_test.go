@@ -0,0 +1,310 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package richtext_test
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/brimstone/plextraccli/richtext"
+	"golang.org/x/net/html"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func goldenFiles(t *testing.T) []string {
+	t.Helper()
+
+	files, err := filepath.Glob(filepath.Join("testdata", "*.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(files) == 0 {
+		t.Fatal("no golden files found")
+	}
+
+	return files
+}
+
+// fingerprint collects everything a reader could see in the HTML: the text,
+// plus the targets of links and images. Whitespace is collapsed since it
+// isn't significant outside of code blocks, which are covered by
+// TestRoundTrip_keeps_structure.
+func fingerprint(t *testing.T, content string) string {
+	t.Helper()
+
+	doc, err := html.Parse(strings.NewReader(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var parts []string
+
+	var walk func(n *html.Node)
+
+	walk = func(n *html.Node) {
+		switch n.Type { //nolint:exhaustive
+		case html.TextNode:
+			parts = append(parts, n.Data)
+		case html.ElementNode:
+			for _, a := range n.Attr {
+				if a.Key == "src" || a.Key == "href" || a.Key == "alt" {
+					parts = append(parts, " "+a.Key+"="+a.Val+" ")
+				}
+			}
+		}
+
+		// Element boundaries separate words even without whitespace in
+		// the source, like adjacent list items.
+		parts = append(parts, " ")
+
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+
+		parts = append(parts, " ")
+	}
+	walk(doc)
+
+	return strings.Join(strings.Fields(strings.Join(parts, "")), " ")
+}
+
+func TestToMarkdown_golden(t *testing.T) {
+	t.Parallel()
+
+	for _, file := range goldenFiles(t) {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			t.Parallel()
+
+			input, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := richtext.ToMarkdown(string(input))
+			if err != nil {
+				t.Fatalf("ToMarkdown() returned error: %v", err)
+			}
+
+			goldenFile := strings.TrimSuffix(file, ".html") + ".md"
+
+			if *update {
+				err = os.WriteFile(goldenFile, []byte(got), 0o600)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			expected, err := os.ReadFile(goldenFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != string(expected) {
+				t.Errorf("ToMarkdown() =\n%s\nwant\n%s", got, expected)
+			}
+		})
+	}
+}
+
+func TestRoundTrip_no_content_loss(t *testing.T) {
+	t.Parallel()
+
+	for _, file := range goldenFiles(t) {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			t.Parallel()
+
+			input, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			md, err := richtext.ToMarkdown(string(input))
+			if err != nil {
+				t.Fatalf("ToMarkdown() returned error: %v", err)
+			}
+
+			out, err := richtext.ToHTML(md)
+			if err != nil {
+				t.Fatalf("ToHTML() returned error: %v", err)
+			}
+
+			want := fingerprint(t, string(input))
+			got := fingerprint(t, out)
+
+			if got != want {
+				t.Errorf("content changed in round trip\ngot:  %s\nwant: %s", got, want)
+			}
+
+			md2, err := richtext.ToMarkdown(out)
+			if err != nil {
+				t.Fatalf("ToMarkdown() returned error: %v", err)
+			}
+
+			if md2 != md {
+				t.Errorf("Markdown isn't stable across a round trip\ngot:\n%s\nwant:\n%s", md2, md)
+			}
+		})
+	}
+}
+
+func TestRoundTrip_keeps_structure(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    string
+		contains []string
+	}{
+		{
+			name:     "figure",
+			input:    `<figure><img src="/api/v1/uploads/a.png"><figcaption>Caption</figcaption></figure>`,
+			contains: []string{"<figure>", `<img src="/api/v1/uploads/a.png"/>`, "<figcaption>Caption</figcaption>", "</figure>"},
+		},
+		{
+			name:     "code block",
+			input:    "<pre><code class=\"language-bash\">id\nuid=0(root)</code></pre>",
+			contains: []string{"<pre><code class=\"language-bash\">id\nuid=0(root)</code></pre>"},
+		},
+		{
+			name:     "table",
+			input:    `<table><thead><tr><th>A</th></tr></thead><tbody><tr><td>1</td></tr></tbody></table>`,
+			contains: []string{"<table>", "<th>A</th>", "<td>1</td>"},
+		},
+		{
+			name:     "nested list stays tight",
+			input:    `<ul><li>a<ul><li>b</li></ul></li></ul>`,
+			contains: []string{"<li>a\n"},
+		},
+		{
+			name:     "headings",
+			input:    `<h1>One</h1><h2>Two</h2><h3>Three</h3>`,
+			contains: []string{"<h1>One</h1>", "<h2>Two</h2>", "<h3>Three</h3>"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			md, err := richtext.ToMarkdown(tt.input)
+			if err != nil {
+				t.Fatalf("ToMarkdown() returned error: %v", err)
+			}
+
+			out, err := richtext.ToHTML(md)
+			if err != nil {
+				t.Fatalf("ToHTML() returned error: %v", err)
+			}
+
+			for _, c := range tt.contains {
+				if !strings.Contains(out, c) {
+					t.Errorf("ToHTML() = %q, want it to contain %q", out, c)
+				}
+			}
+
+			if strings.Contains(out, "THE END") {
+				t.Errorf("ToHTML() = %q, leaked the list end comment", out)
+			}
+		})
+	}
+}
+
+func TestFromText(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in       string
+		expected string
+	}{
+		{"", ""},
+		{"one line", "<p>one line</p>"},
+		{"first\r\nsecond\n\n\nnext <b>", "<p>first<br />second</p><p>next &lt;b&gt;</p>"},
+	}
+
+	for _, tt := range tests {
+		got := richtext.FromText(tt.in)
+		if got != tt.expected {
+			t.Errorf("FromText(%q) = %q, want %q", tt.in, got, tt.expected)
+		}
+	}
+}
+
+func TestCodeBlock_round_trips(t *testing.T) {
+	t.Parallel()
+
+	request := "GET /?q=<script> HTTP/1.1\r\nHost: example.com\r\n\r\n"
+
+	md, err := richtext.ToMarkdown(richtext.CodeBlock(request))
+	if err != nil {
+		t.Fatalf("ToMarkdown() returned error: %v", err)
+	}
+
+	if !strings.Contains(md, "GET /?q=<script> HTTP/1.1\nHost: example.com") {
+		t.Errorf("expected the request in a code block, got %q", md)
+	}
+}
+
+func TestLinkList(t *testing.T) {
+	t.Parallel()
+
+	expected := `<ul><li><a href="https://example.com/?a=1&amp;b=2">https://example.com/?a=1&amp;b=2</a></li></ul>`
+
+	got := richtext.LinkList([]string{"https://example.com/?a=1&b=2", " "})
+	if got != expected {
+		t.Errorf("LinkList() = %q, want %q", got, expected)
+	}
+
+	if got := richtext.LinkList(nil); got != "" {
+		t.Errorf("expected no list without links, got %q", got)
+	}
+}
+
+func TestFigure(t *testing.T) {
+	t.Parallel()
+
+	expected := `<figure><img src="/api/v1/uploads/a.png" alt="Hashes &amp; more"><figcaption>Hashes &amp; more</figcaption></figure>`
+
+	got := richtext.Figure("/api/v1/uploads/a.png", " Hashes & more. ")
+	if got != expected {
+		t.Errorf("Figure() = %q, want %q", got, expected)
+	}
+}
+
+func TestCaptionedCodeBlock(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		text     string
+		caption  string
+		expected string
+	}{
+		{
+			name:     "caption",
+			text:     "SMB 10.0.0.5 445 DC01 [+] corp\\admin:<hash>\n",
+			caption:  "NetExec output.",
+			expected: "<p><strong>NetExec output</strong></p><pre><code>SMB 10.0.0.5 445 DC01 [+] corp\\admin:&lt;hash&gt;</code></pre>",
+		},
+		{
+			name:     "no caption",
+			text:     "id\n",
+			expected: "<pre><code>id</code></pre>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := richtext.CaptionedCodeBlock(tt.text, tt.caption)
+			if got != tt.expected {
+				t.Errorf("CaptionedCodeBlock() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
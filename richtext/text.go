@@ -0,0 +1,180 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package richtext
+
+import (
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// codeTags hold text that has to be left exactly as it is.
+var codeTags = []string{"pre", "code", "script", "style"}
+
+// textEscaper escapes only what has to be escaped in text, so quotes don't
+// turn into entities.
+var textEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", "\u00a0", "&nbsp;")
+
+// MapText rewrites the prose of the rich text with f, leaving the markup and
+// anything in code blocks alone. f gets the text unescaped, and what it
+// changes is escaped again.
+func MapText(content string, f func(string) string) string {
+	var b strings.Builder
+
+	z := html.NewTokenizer(strings.NewReader(content))
+
+	inCode := 0
+
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			if z.Err() != io.EOF {
+				// Give up on what can't be tokenized rather than lose it
+				return content
+			}
+
+			return b.String()
+		}
+
+		// TagName lowercases in place, so copy raw before it's changed
+		raw := slices.Clone(z.Raw())
+
+		switch tt {
+		case html.StartTagToken, html.EndTagToken:
+			name, _ := z.TagName()
+			if slices.Contains(codeTags, string(name)) {
+				if tt == html.StartTagToken {
+					inCode++
+				} else if inCode > 0 {
+					inCode--
+				}
+			}
+		case html.TextToken:
+			if inCode > 0 {
+				break
+			}
+
+			text := html.UnescapeString(string(raw))
+			if mapped := f(text); mapped != text {
+				b.WriteString(textEscaper.Replace(mapped))
+
+				continue
+			}
+		}
+
+		b.Write(raw)
+	}
+}
+
+// blockTags start and end paragraphs of text.
+var blockTags = []string{
+	"p", "li", "td", "th", "tr", "dt", "dd", "div", "blockquote", "figcaption",
+	"h1", "h2", "h3", "h4", "h5", "h6", "br", "ul", "ol", "table", "figure",
+}
+
+// Paragraphs returns the text of each paragraph, list item, heading, caption
+// and table cell of the rich text, with the whitespace collapsed, leaving
+// out code.
+func Paragraphs(content string) []string {
+	var paragraphs []string
+
+	var b strings.Builder
+
+	flush := func() {
+		if text := strings.Join(strings.Fields(b.String()), " "); text != "" {
+			paragraphs = append(paragraphs, text)
+		}
+
+		b.Reset()
+	}
+
+	z := html.NewTokenizer(strings.NewReader(content))
+
+	inCode := 0
+
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			flush()
+
+			return paragraphs
+		}
+
+		switch tt {
+		case html.StartTagToken, html.EndTagToken, html.SelfClosingTagToken:
+			name, _ := z.TagName()
+
+			switch {
+			case slices.Contains(codeTags, string(name)):
+				if tt == html.StartTagToken {
+					inCode++
+				} else if tt == html.EndTagToken && inCode > 0 {
+					inCode--
+				}
+			case slices.Contains(blockTags, string(name)):
+				flush()
+			}
+		case html.TextToken:
+			if inCode == 0 {
+				b.Write(z.Text())
+			}
+		}
+	}
+}
+
+// textURL is a URL in text, or something meant to be one, eg: http//example.com
+var textURL = regexp.MustCompile(`(?i)\b(?:h[tx]{2}ps?[:/]|[a-z][a-z0-9+.-]*://|www\.)[^\s<>"']*`)
+
+// Links returns the targets of the links in the rich text, and the URLs in
+// its text outside of links, in order, duplicates and all.
+func Links(content string) []string {
+	var links []string
+
+	z := html.NewTokenizer(strings.NewReader(content))
+
+	inLink := 0
+
+	for {
+		tt := z.Next()
+
+		switch tt {
+		case html.ErrorToken:
+			return links
+		case html.StartTagToken, html.EndTagToken:
+			name, hasAttr := z.TagName()
+			if string(name) != "a" {
+				continue
+			}
+
+			if tt == html.EndTagToken {
+				if inLink > 0 {
+					inLink--
+				}
+
+				continue
+			}
+
+			inLink++
+
+			for hasAttr {
+				var key, val []byte
+
+				key, val, hasAttr = z.TagAttr()
+				if string(key) == "href" {
+					links = append(links, string(val))
+				}
+			}
+		case html.TextToken:
+			if inLink > 0 {
+				continue
+			}
+
+			for _, u := range textURL.FindAllString(html.UnescapeString(string(z.Text())), -1) {
+				links = append(links, strings.TrimRight(u, ".,;:!?)]}"))
+			}
+		}
+	}
+}
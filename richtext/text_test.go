@@ -0,0 +1,85 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package richtext_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/brimstone/plextraccli/richtext"
+)
+
+func TestMapText(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name:     "prose",
+			content:  "<p>foo <strong>foo</strong></p>",
+			expected: "<p>bar <strong>bar</strong></p>",
+		},
+		{
+			name:     "code untouched",
+			content:  "<p>foo</p><pre><code>foo &lt;foo&gt;</code></pre><code>foo</code>",
+			expected: "<p>bar</p><pre><code>foo &lt;foo&gt;</code></pre><code>foo</code>",
+		},
+		{
+			name:     "entities kept",
+			content:  "<p>foo &amp; &lt;foo&gt;</p><p>&nbsp;&amp;</p>",
+			expected: "<p>bar &amp; &lt;bar&gt;</p><p>&nbsp;&amp;</p>",
+		},
+		{
+			name:     "markup untouched",
+			content:  `<A HREF="foo">foo</A><!-- foo -->`,
+			expected: `<A HREF="foo">bar</A><!-- foo -->`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := richtext.MapText(tt.content, func(text string) string {
+				return strings.ReplaceAll(text, "foo", "bar")
+			})
+			if got != tt.expected {
+				t.Errorf("MapText() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParagraphs(t *testing.T) {
+	t.Parallel()
+
+	content := "<h1>Attack   Path</h1><p>The tester ran <code>nxc</code> against\n<strong>every</strong> host.<br>Then stopped.</p>" +
+		"<pre><code>nxc smb 10.0.0.0/24</code></pre><ul><li>One &amp; two</li><li></li></ul>" +
+		"<figure><img src=\"a.png\"><figcaption>Hashes</figcaption></figure>"
+
+	expected := []string{"Attack Path", "The tester ran against every host.", "Then stopped.", "One & two", "Hashes"}
+
+	got := richtext.Paragraphs(content)
+	if !slices.Equal(got, expected) {
+		t.Errorf("Paragraphs() = %q, want %q", got, expected)
+	}
+}
+
+func TestLinks(t *testing.T) {
+	t.Parallel()
+
+	content := `<p>See <a href="https://example.com/a">the advisory</a> and https://example.com/b.</p>` +
+		`<ul><li>(www.example.com/c)</li><li>http//example.com/d</li><li>HTTPS is required</li></ul>` +
+		`<pre><code>curl http://10.0.0.1/</code></pre>`
+
+	expected := []string{"https://example.com/a", "https://example.com/b", "www.example.com/c", "http//example.com/d", "http://10.0.0.1/"}
+
+	got := richtext.Links(content)
+	if !slices.Equal(got, expected) {
+		t.Errorf("Links() = %q, want %q", got, expected)
+	}
+}
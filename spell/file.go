@@ -0,0 +1,95 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package spell
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// DefaultFile is the team dictionary, in the home directory, when the config
+// doesn't name one.
+const DefaultFile = ".plextrac-dictionary.txt"
+
+// ReadFile reads the words of a dictionary file, one per line, skipping
+// comments. A file that doesn't exist yet has no words.
+func ReadFile(path string) ([]string, error) {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to read dictionary: %w", err)
+	}
+
+	var words []string
+
+	for line := range strings.Lines(string(content)) {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		words = append(words, line)
+	}
+
+	return words, nil
+}
+
+// AddToFile adds words to the end of a dictionary file, creating it if
+// needed, and returns the words that weren't in it already.
+func AddToFile(path string, words ...string) ([]string, error) {
+	existing, err := ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var added []string
+
+	for _, w := range words {
+		w = strings.TrimSpace(w)
+		if w == "" || strings.ContainsFunc(w, unicode.IsSpace) {
+			return nil, fmt.Errorf("%q is not a word", w)
+		}
+
+		if slices.ContainsFunc(existing, func(e string) bool { return strings.EqualFold(e, w) }) {
+			continue
+		}
+
+		existing = append(existing, w)
+		added = append(added, w)
+	}
+
+	if len(added) == 0 {
+		return nil, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("unable to read dictionary: %w", err)
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open dictionary: %w", err)
+	}
+	defer f.Close()
+
+	text := strings.Join(added, "\n") + "\n"
+	if len(content) > 0 && !strings.HasSuffix(string(content), "\n") {
+		text = "\n" + text
+	}
+
+	_, err = f.WriteString(text)
+	if err != nil {
+		return nil, fmt.Errorf("unable to write dictionary: %w", err)
+	}
+
+	return added, f.Close()
+}
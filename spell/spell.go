@@ -0,0 +1,381 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+// Package spell checks spelling offline, against an embedded English word
+// list, security terms and a team dictionary of tool names, protocols and
+// acronyms.
+//
+// Words are known ignoring case, and with common prefixes and suffixes
+// removed, so the word lists only need the stems of most words. What isn't
+// prose, such as hashes, IP addresses, URLs, paths and file names, is
+// skipped, as are words in all caps or with capitals inside them, which are
+// usually acronyms and product names.
+package spell
+
+import (
+	_ "embed"
+	"net"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+//go:embed words.txt
+var englishWords string
+
+//go:embed terms.txt
+var securityTerms string
+
+// Dictionary is the words spelled correctly.
+type Dictionary struct {
+	// ranks are how common the words are, lower is more common
+	ranks map[string]int
+	words []string
+}
+
+// Misspelling is a word that isn't in the dictionary.
+type Misspelling struct {
+	Word string
+	// Count is how many times the word is misspelled
+	Count       int
+	Suggestions []string
+}
+
+// New returns a dictionary of the English words and security terms, and any
+// other words.
+func New(words ...string) *Dictionary {
+	d := &Dictionary{
+		ranks: make(map[string]int),
+	}
+
+	d.add(englishWords)
+	d.add(securityTerms)
+	d.Add(words...)
+
+	return d
+}
+
+// add adds the words of a word list, one per line, skipping comments.
+func (d *Dictionary) add(list string) {
+	for line := range strings.Lines(list) {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		d.Add(line)
+	}
+}
+
+// Add adds words to the dictionary.
+func (d *Dictionary) Add(words ...string) {
+	for _, w := range words {
+		w = normalize(w)
+		if w == "" {
+			continue
+		}
+
+		if _, ok := d.ranks[w]; ok {
+			continue
+		}
+
+		d.ranks[w] = len(d.words)
+		d.words = append(d.words, w)
+	}
+}
+
+func normalize(word string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(word), "’", "'"))
+}
+
+// prefixes are removed from words that aren't known as they are.
+var prefixes = []string{"un", "re", "non", "pre", "sub", "de", "dis", "mis", "over", "under", "multi", "inter", "co"}
+
+// suffixes are replaced, to find the stems of words that aren't known as
+// they are, eg: policies is known by policy.
+var suffixes = []struct {
+	suffix  string
+	replace string
+}{
+	{"'s", ""},
+	{"'", ""},
+	{"ies", "y"},
+	{"ied", "y"},
+	{"ier", "y"},
+	{"iest", "y"},
+	{"ily", "y"},
+	{"es", ""},
+	{"s", ""},
+	{"ed", ""},
+	{"ed", "e"},
+	{"ing", ""},
+	{"ing", "e"},
+	{"ly", ""},
+	{"er", ""},
+	{"er", "e"},
+	{"ers", ""},
+	{"ers", "e"},
+	{"est", ""},
+	{"ness", ""},
+	{"ment", ""},
+	{"ments", ""},
+	{"able", ""},
+	{"able", "e"},
+	{"ability", ""},
+	{"ability", "e"},
+	{"ation", ""},
+	{"ation", "e"},
+	{"ations", ""},
+	{"ations", "e"},
+	{"ion", ""},
+	{"ion", "e"},
+	{"ions", ""},
+	{"ions", "e"},
+	{"ize", ""},
+	{"ized", ""},
+	{"izes", ""},
+	{"izing", ""},
+}
+
+// Known reports whether the word is spelled correctly.
+func (d *Dictionary) Known(word string) bool {
+	word = normalize(word)
+
+	if d.knownStem(word) {
+		return true
+	}
+
+	for _, p := range prefixes {
+		if rest, ok := strings.CutPrefix(word, p); ok && len(rest) >= 3 && d.knownStem(strings.TrimPrefix(rest, "-")) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (d *Dictionary) knownStem(word string) bool {
+	if _, ok := d.ranks[word]; ok {
+		return true
+	}
+
+	for _, s := range suffixes {
+		stem, ok := strings.CutSuffix(word, s.suffix)
+		if !ok || len(stem) < 2 {
+			continue
+		}
+
+		if _, ok := d.ranks[stem+s.replace]; ok {
+			// Unless the consonant should have been doubled, eg: occured
+			if _, doubled := d.ranks[stem+stem[len(stem)-1:]+s.suffix]; s.replace == "" && doubled {
+				return false
+			}
+
+			return true
+		}
+
+		// Doubled consonants, eg: stopped
+		n := len(stem)
+		if s.replace == "" && n > 2 && stem[n-1] == stem[n-2] {
+			if _, ok := d.ranks[stem[:n-1]]; ok {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Suggest returns up to n known words that are close to the word, closest
+// and most common first, in the same case as the word.
+func (d *Dictionary) Suggest(word string, n int) []string {
+	lower := normalize(word)
+
+	maxDistance := 2
+	if len(lower) <= 4 {
+		maxDistance = 1
+	}
+
+	type candidate struct {
+		word     string
+		distance int
+		rank     int
+	}
+
+	var candidates []candidate
+
+	for rank, w := range d.words {
+		if abs(len(w)-len(lower)) > maxDistance {
+			continue
+		}
+
+		if distance := editDistance(lower, w, maxDistance); distance <= maxDistance {
+			candidates = append(candidates, candidate{w, distance, rank})
+		}
+	}
+
+	slices.SortStableFunc(candidates, func(a, b candidate) int {
+		if a.distance != b.distance {
+			return a.distance - b.distance
+		}
+
+		return a.rank - b.rank
+	})
+
+	var suggestions []string
+
+	for _, c := range candidates {
+		if len(suggestions) == n {
+			break
+		}
+
+		suggestions = append(suggestions, matchCase(word, c.word))
+	}
+
+	return suggestions
+}
+
+// matchCase spells the suggestion in the case of the word.
+func matchCase(word string, suggestion string) string {
+	switch {
+	case strings.ToUpper(word) == word:
+		return strings.ToUpper(suggestion)
+	case unicode.IsUpper([]rune(word)[0]):
+		r := []rune(suggestion)
+		r[0] = unicode.ToUpper(r[0])
+
+		return string(r)
+	}
+
+	return suggestion
+}
+
+func abs(i int) int {
+	if i < 0 {
+		return -i
+	}
+
+	return i
+}
+
+// editDistance is the number of insertions, deletions, substitutions and
+// transpositions to get from a to b, or more than max once it's more.
+func editDistance(a string, b string, maxDistance int) int {
+	ra, rb := []rune(a), []rune(b)
+
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		lowest := cur[0]
+
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+
+			lowest = min(lowest, cur[j])
+		}
+
+		if lowest > maxDistance {
+			return maxDistance + 1
+		}
+
+		prev2, prev, cur = prev, cur, prev2
+	}
+
+	return prev[len(rb)]
+}
+
+// wordPattern is a word, with apostrophes inside it.
+var wordPattern = regexp.MustCompile(`\p{L}+(?:['’]\p{L}+)*`)
+
+// hexPattern is a hash, or anything else long and hex.
+var hexPattern = regexp.MustCompile(`^(?i)(?:0x)?[0-9a-f]{12,}$`)
+
+// skipped reports whether a token isn't prose, such as a URL, email
+// address, path, file or host name, IP address or hash.
+func skipped(token string) bool {
+	if strings.Contains(token, "://") || strings.HasPrefix(strings.ToLower(token), "www.") {
+		return true
+	}
+
+	if strings.ContainsAny(token, `@/\_=$%#{}<>|~^`) {
+		return true
+	}
+
+	if strings.ContainsFunc(token, unicode.IsDigit) {
+		return true
+	}
+
+	// Host and file names, eg: dc01.corp.local, ntlmrelayx.py
+	if strings.Contains(strings.Trim(token, "."), ".") {
+		return true
+	}
+
+	if net.ParseIP(token) != nil || hexPattern.MatchString(token) {
+		return true
+	}
+
+	// IPv6 addresses without digits, and hashes in parts, eg: LM:NT
+	return strings.Count(token, ":") > 1
+}
+
+// skippedWord reports whether a word is an acronym or product name, by its
+// case, eg: SMB or PowerView.
+func skippedWord(word string) bool {
+	r := []rune(word)
+	if len(r) < 2 {
+		return true
+	}
+
+	return slices.ContainsFunc(r[1:], unicode.IsUpper)
+}
+
+// Check finds the misspelled words in the text, in the order they're first
+// found.
+func (d *Dictionary) Check(text string) []Misspelling {
+	var misspellings []Misspelling
+
+	for _, token := range strings.Fields(text) {
+		token = strings.Trim(token, `"'()[]{}<>,.;:!?*‘’“”`)
+		if token == "" || skipped(token) {
+			continue
+		}
+
+		for _, word := range wordPattern.FindAllString(token, -1) {
+			// Prefixes on their own are from hyphenated words, eg: re-run
+			if skippedWord(word) || slices.Contains(prefixes, strings.ToLower(word)) || d.Known(word) {
+				continue
+			}
+
+			i := slices.IndexFunc(misspellings, func(m Misspelling) bool { return m.Word == word })
+			if i != -1 {
+				misspellings[i].Count++
+
+				continue
+			}
+
+			misspellings = append(misspellings, Misspelling{
+				Word:        word,
+				Count:       1,
+				Suggestions: d.Suggest(word, 3),
+			})
+		}
+	}
+
+	return misspellings
+}
@@ -0,0 +1,167 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package spell_test
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/brimstone/plextraccli/spell"
+)
+
+func TestDictionary_Check(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		text     string
+		expected []string
+	}{
+		{
+			name: "clean",
+			text: "The attacker relayed credentials to the domain controller, and the hosts didn't require SMB signing.",
+		},
+		{
+			name:     "misspellings",
+			text:     "Teh attacker recieved hashes, which occured twice. Teh end.",
+			expected: []string{"Teh", "recieved", "occured"},
+		},
+		{
+			name: "stems",
+			text: "Policies were misconfigured, remediated and retested; enumerating users escalated privileges.",
+		},
+		{
+			name: "not prose",
+			text: "See https://example.com/docs, admin@corp.local, C:\\Windows\\System32, dc01.corp.local, " +
+				"10.0.0.1, fe80::1, aad3b435b51404eeaad3b435b51404ee:31d6cfe0d16ae931b73c59d7e0c089c0 and ntlmrelayx.py",
+		},
+		{
+			name: "acronyms and product names",
+			text: "The XYZQ service and PowerView were used.",
+		},
+		{
+			name: "hyphenated",
+			text: "The hosts were re-scanned.",
+		},
+	}
+
+	d := spell.New()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got []string
+			for _, m := range d.Check(tt.text) {
+				got = append(got, m.Word)
+			}
+
+			if !slices.Equal(got, tt.expected) {
+				t.Errorf("Check() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDictionary_Check_count(t *testing.T) {
+	t.Parallel()
+
+	misspellings := spell.New().Check("Teh end, teh end, Teh end.")
+	if len(misspellings) != 2 || misspellings[0].Count != 2 || misspellings[1].Count != 1 {
+		t.Errorf("unexpected misspellings %+v", misspellings)
+	}
+}
+
+func TestDictionary_Suggest(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		word     string
+		expected string
+	}{
+		{"recieve", "receive"},
+		{"controler", "controller"},
+		{"Occured", "Occurred"},
+		{"ACCROSS", "ACROSS"},
+	}
+
+	d := spell.New()
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			t.Parallel()
+
+			suggestions := d.Suggest(tt.word, 3)
+			if !slices.Contains(suggestions, tt.expected) {
+				t.Errorf("Suggest(%q) = %v, want %q in it", tt.word, suggestions, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDictionary_Add(t *testing.T) {
+	t.Parallel()
+
+	d := spell.New("Bloodhund")
+	d.Add("Sharpie's")
+
+	for _, w := range []string{"bloodhund", "BLOODHUND", "sharpie's"} {
+		if !d.Known(w) {
+			t.Errorf("expected %q to be known", w)
+		}
+	}
+}
+
+func TestAddToFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "dictionary.txt")
+
+	err := os.WriteFile(path, []byte("# Tools\nNetExec"), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	added, err := spell.AddToFile(path, "netexec", "Certipy", "nxc")
+	if err != nil {
+		t.Fatalf("AddToFile() returned error: %v", err)
+	}
+
+	if !slices.Equal(added, []string{"Certipy", "nxc"}) {
+		t.Errorf("added = %v", added)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(content) != "# Tools\nNetExec\nCertipy\nnxc\n" {
+		t.Errorf("file = %q", content)
+	}
+
+	words, err := spell.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() returned error: %v", err)
+	}
+
+	if !slices.Equal(words, []string{"NetExec", "Certipy", "nxc"}) {
+		t.Errorf("words = %v", words)
+	}
+
+	_, err = spell.AddToFile(path, "two words")
+	if err == nil {
+		t.Error("expected an error adding two words")
+	}
+}
+
+func TestReadFile_missing(t *testing.T) {
+	t.Parallel()
+
+	words, err := spell.ReadFile(filepath.Join(t.TempDir(), "missing.txt"))
+	if err != nil || words != nil {
+		t.Errorf("ReadFile() = %v, %v, want no words", words, err)
+	}
+}
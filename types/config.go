@@ -17,9 +17,87 @@ type ConfigTag struct {
 
 type LintConfig struct {
 	RequiredSections []RequiredSection `mapstructure:"requiredsections"`
+	// Enable and Disable are rule IDs or names, to turn on rules that are
+	// off by default, or turn off any rule
+	Enable  []string `mapstructure:"enable"`
+	Disable []string `mapstructure:"disable"`
+	// Severity re-levels rules by ID or name, to error, warning, info or off
+	Severity map[string]string `mapstructure:"severity"`
+	// ToolNames are more names to spell with the right case, on top of the
+	// built in ones
+	ToolNames []string   `mapstructure:"toolnames"`
+	Rules     []LintRule `mapstructure:"rules"`
+	// Dictionary is the team dictionary file for spell checking, one word
+	// per line, and Words are more words to add to it
+	Dictionary string   `mapstructure:"dictionary"`
+	Words      []string `mapstructure:"words"`
+	// Grammar configures the grammar rules by the kind of narrative, the
+	// first that matches winning
+	Grammar []GrammarProfile `mapstructure:"grammar"`
+}
+
+// GrammarProfile configures the grammar rules for a kind of narrative, or for
+// findings.
+type GrammarProfile struct {
+	// Sections are globs of the titles of narratives, ignoring case, eg:
+	// *narrative*
+	Sections []string `mapstructure:"sections"`
+	// Findings is whether the profile is for the text of findings
+	Findings bool `mapstructure:"findings"`
+	// Disable are the grammar rules to turn off for these
+	Disable []string `mapstructure:"disable"`
+	// Tense is past to flag narration in the present tense
+	Tense string `mapstructure:"tense"`
+	// MaxPassive is the most of the sentences that can be in the passive
+	// voice, eg: 0.25
+	MaxPassive float64 `mapstructure:"maxpassive"`
+	// MaxWords is the most words in a sentence
+	MaxWords int `mapstructure:"maxwords"`
+}
+
+// LintRule is a custom lint rule.
+type LintRule struct {
+	ID          string `mapstructure:"id"`
+	Name        string `mapstructure:"name"`
+	Description string `mapstructure:"description"`
+	// Type is regex, banned, required or case
+	Type string `mapstructure:"type"`
+	// Pattern is the regular expression for regex rules
+	Pattern string `mapstructure:"pattern"`
+	// Phrases are banned, required or spelled with the right case
+	Phrases  []string `mapstructure:"phrases"`
+	Message  string   `mapstructure:"message"`
+	Severity string   `mapstructure:"severity"`
+	// Scopes are any of sections, evidence, descriptions and
+	// recommendations, or all of them when empty
+	Scopes []string `mapstructure:"scopes"`
+	// Titles limit the narrative sections checked to those with these titles
+	Titles []string `mapstructure:"titles"`
 }
 
 type RequiredSection struct {
 	Section string   `mapstructure:"section"`
 	Tags    []string `mapstructure:"tags"`
 }
+
+type ImportConfig struct {
+	MinSeverity   string   `mapstructure:"minseverity"`
+	IgnorePlugins []string `mapstructure:"ignoreplugins"`
+	Tags          []string `mapstructure:"tags"`
+	// CSVColumns maps importer.CSVFields to column names
+	CSVColumns map[string]string `mapstructure:"csvcolumns"`
+}
+
+type RedactConfig struct {
+	// Disable are names of built in detectors to turn off
+	Disable  []string        `mapstructure:"disable"`
+	Patterns []RedactPattern `mapstructure:"patterns"`
+}
+
+type RedactPattern struct {
+	Name        string `mapstructure:"name"`
+	Description string `mapstructure:"description"`
+	// Pattern is a regular expression, where the subexpression named
+	// secret is masked, or the whole match without one
+	Pattern string `mapstructure:"pattern"`
+}
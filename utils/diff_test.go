@@ -0,0 +1,67 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/brimstone/plextraccli/utils"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		before   string
+		after    string
+		expected string
+	}{
+		{
+			name:     "same",
+			before:   "a\nb\n",
+			after:    "a\nb\n",
+			expected: "",
+		},
+		{
+			name:   "changed line",
+			before: "a\nb\nc\n",
+			after:  "a\nB\nc\n",
+			expected: "--- before\n+++ after\n" +
+				"@@ -1,3 +1,3 @@\n a\n-b\n+B\n c\n",
+		},
+		{
+			name:   "added line at end",
+			before: "a\n",
+			after:  "a\nb\n",
+			expected: "--- before\n+++ after\n" +
+				"@@ -1 +1,2 @@\n a\n+b\n",
+		},
+		{
+			name:   "from empty",
+			before: "",
+			after:  "a\n",
+			expected: "--- before\n+++ after\n" +
+				"@@ -0,0 +1 @@\n+a\n",
+		},
+		{
+			name:   "separate hunks",
+			before: "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n",
+			after:  "one\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\ntwelve\n",
+			expected: "--- before\n+++ after\n" +
+				"@@ -1,4 +1,4 @@\n-1\n+one\n 2\n 3\n 4\n" +
+				"@@ -9,4 +9,4 @@\n 9\n 10\n 11\n-12\n+twelve\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := utils.UnifiedDiff(tt.before, tt.after, "before", "after")
+			if got != tt.expected {
+				t.Errorf("UnifiedDiff() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
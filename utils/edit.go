@@ -0,0 +1,43 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package utils
+
+import (
+	"fmt"
+
+	"github.com/brimstone/plextraccli/richtext"
+)
+
+// EditHTMLAsMarkdown lets the user edit HTML content as Markdown in their
+// editor. The diff of the change is shown and the new HTML is only returned
+// as changed once the user confirms it.
+func EditHTMLAsMarkdown(content string, name string) (string, bool, error) {
+	md, err := richtext.ToMarkdown(content)
+	if err != nil {
+		return "", false, err
+	}
+
+	edited, err := EditString(md+"\n", ".md")
+	if err != nil {
+		return "", false, err
+	}
+
+	diff := UnifiedDiff(md+"\n", edited, name, name)
+	if diff == "" {
+		return content, false, nil
+	}
+
+	fmt.Print(diff)
+
+	ok, err := Confirm("Save changes to " + name + "?")
+	if err != nil || !ok {
+		return content, false, err
+	}
+
+	edited, err = richtext.ToHTML(edited)
+	if err != nil {
+		return content, false, err
+	}
+
+	return edited, true, nil
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package utils
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// EditString opens content in the user's $VISUAL or $EDITOR and returns the
+// edited result. The extension is used for the temporary file so editors pick
+// the right syntax highlighting.
+func EditString(content string, extension string) (string, error) {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+
+	if editor == "" {
+		editor = "vi"
+	}
+
+	file, err := os.CreateTemp("", "plextrac-*"+extension)
+	if err != nil {
+		return "", err
+	}
+
+	defer func() { _ = os.Remove(file.Name()) }()
+
+	_, err = file.WriteString(content)
+	if err != nil {
+		return "", err
+	}
+
+	err = file.Close()
+	if err != nil {
+		return "", err
+	}
+
+	// $EDITOR is allowed to have arguments, like "code --wait"
+	fields := strings.Fields(editor)
+
+	cmd := exec.Command(fields[0], append(fields[1:], file.Name())...) //nolint:gosec
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	err = cmd.Run()
+	if err != nil {
+		return "", fmt.Errorf("while running editor %q: %w", editor, err)
+	}
+
+	edited, err := os.ReadFile(file.Name())
+	if err != nil {
+		return "", err
+	}
+
+	return string(edited), nil
+}
+
+// Confirm asks a yes/no question on stdout and reads the answer from stdin.
+// Anything other than y or yes is a no.
+func Confirm(prompt string) (bool, error) {
+	fmt.Printf("%s [y/N] ", prompt)
+
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && answer == "" {
+		return false, err
+	}
+
+	answer = strings.ToLower(strings.TrimSpace(answer))
+
+	return answer == "y" || answer == "yes", nil
+}
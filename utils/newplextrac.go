@@ -3,6 +3,7 @@
 package utils
 
 import (
+	"errors"
 	"log/slog"
 	"time"
 
@@ -50,3 +51,50 @@ func NewPlextrac() (*plextrac.UserAgent, []error, error) {
 		},
 	})
 }
+
+// GetClient is the client --client partially names.
+func GetClient() (*plextrac.UserAgent, *plextrac.Client, []error, error) {
+	p, warnings, err := NewPlextrac()
+	if err != nil {
+		return nil, nil, warnings, err
+	}
+
+	clientPartial := viper.GetString("client")
+	if clientPartial == "" {
+		return nil, nil, warnings, errors.New("must specify a client")
+	}
+
+	c, err := p.ClientByPartial(clientPartial)
+	if err != nil {
+		return nil, nil, warnings, err
+	}
+
+	return p, c, warnings, nil
+}
+
+// ClientReport is the report of the client --report partially names.
+func ClientReport(c *plextrac.Client) (*plextrac.Report, []error, error) {
+	reportPartial := viper.GetString("report")
+	if reportPartial == "" {
+		return nil, nil, errors.New("must specify a report")
+	}
+
+	return c.ReportByPartial(reportPartial)
+}
+
+// GetReport is the report --client and --report partially name.
+func GetReport() (*plextrac.UserAgent, *plextrac.Report, []error, error) {
+	p, c, warnings, err := GetClient()
+	if err != nil {
+		return nil, nil, warnings, err
+	}
+
+	r, warnings2, err := ClientReport(c)
+	warnings = append(warnings, warnings2...)
+
+	if err != nil {
+		return nil, nil, warnings, err
+	}
+
+	return p, r, warnings, nil
+}
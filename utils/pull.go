@@ -0,0 +1,54 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package utils
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/brimstone/plextraccli/plextrac"
+	"github.com/brimstone/plextraccli/richtext"
+)
+
+// PullFormats are the formats PullRichText can write.
+var PullFormats = []string{
+	"html",
+	"md",
+}
+
+// PullRichText downloads the images of the rich text into dir and writes
+// the rich text next to them, as HTML or Markdown, pointing at the local
+// copies. Files are named after name, so pulling again overwrites the same
+// files. It returns the path of the written rich text and its images.
+func PullRichText(ua *plextrac.UserAgent, content string, dir string, name string, format string) (string, []string, error) {
+	content, images, err := ua.PullImages(content, dir, name)
+	if err != nil {
+		return "", images, err
+	}
+
+	switch format {
+	case "html":
+	case "md":
+		content, err = richtext.ToMarkdown(content)
+		if err != nil {
+			return "", images, err
+		}
+	default:
+		return "", images, fmt.Errorf("unsupported format: %s", format)
+	}
+
+	err = os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return "", images, err
+	}
+
+	filename := filepath.Join(dir, name+"."+format)
+
+	err = os.WriteFile(filename, []byte(content+"\n"), 0o644) //nolint:gosec
+	if err != nil {
+		return "", images, err
+	}
+
+	return filename, images, nil
+}
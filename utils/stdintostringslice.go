@@ -4,15 +4,27 @@ package utils
 
 import (
 	"bufio"
+	"io"
 	"os"
+	"strings"
 )
 
 func StdinToStringSlice() ([]string, error) {
+	return ReadLines(os.Stdin)
+}
+
+// ReadLines reads the lines of r, trimmed, skipping blank ones.
+func ReadLines(r io.Reader) ([]string, error) {
 	var ret []string
 
-	scanner := bufio.NewScanner(os.Stdin)
+	scanner := bufio.NewScanner(r)
 	for scanner.Scan() {
-		ret = append(ret, scanner.Text())
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		ret = append(ret, line)
 	}
 
 	err := scanner.Err()
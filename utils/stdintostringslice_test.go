@@ -0,0 +1,41 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package utils_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/brimstone/plextraccli/utils"
+)
+
+func TestReadLines(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{name: "empty"},
+		{name: "blank lines", input: "\n  \n\t\n"},
+		{name: "lines", input: "dc01\nws01:445\n", expected: []string{"dc01", "ws01:445"}},
+		{name: "blank lines between", input: "dc01\n\n  ws01 \r\n\n", expected: []string{"dc01", "ws01"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := utils.ReadLines(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("ReadLines() returned error: %v", err)
+			}
+
+			if !slices.Equal(got, tt.expected) {
+				t.Errorf("ReadLines() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
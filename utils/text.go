@@ -0,0 +1,87 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ansiEscape matches terminal escape sequences, colors and cursor movement
+// alike.
+var ansiEscape = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]|\x1b\][^\x07\x1b]*(?:\x07|\x1b\\)|\x1b[@-Z\\-_]`)
+
+// StripANSI removes terminal escape sequences, such as colors, from command
+// output.
+func StripANSI(text string) string {
+	return ansiEscape.ReplaceAllString(text, "")
+}
+
+// Truncate shortens text to at most maxLines lines, keeping the start and
+// end and marking what was cut out of the middle. A maxLines of 0 or less
+// keeps everything.
+func Truncate(text string, maxLines int) string {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	if maxLines <= 0 || len(lines) <= maxLines {
+		return text
+	}
+
+	head := (maxLines + 1) / 2
+	tail := maxLines - head
+
+	kept := append([]string{}, lines[:head]...)
+	kept = append(kept, fmt.Sprintf("[… %d lines truncated …]", len(lines)-maxLines))
+	kept = append(kept, lines[len(lines)-tail:]...)
+
+	return strings.Join(kept, "\n") + "\n"
+}
+
+// Slug turns a name, such as a finding's title, into something safe to use
+// as a file name: lowercase letters, numbers and dashes.
+func Slug(name string) string {
+	var b strings.Builder
+
+	dash := false
+
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+
+			b.WriteRune(r)
+			dash = false
+
+			continue
+		}
+
+		dash = true
+	}
+
+	if b.Len() == 0 {
+		return "untitled"
+	}
+
+	return b.String()
+}
+
+// Slugs hands out slugs that are unique among the ones it has given, so
+// titles that slug the same, eg: "SMB Signing" and "SMB signing!", don't
+// overwrite each other's files. The second gets a -2 suffix, and so on.
+type Slugs map[string]bool
+
+// Slug is the slug of name, with a numeric suffix if it's already been used.
+func (s Slugs) Slug(name string) string {
+	base := Slug(name)
+	slug := base
+
+	for i := 2; s[slug]; i++ {
+		slug = base + "-" + strconv.Itoa(i)
+	}
+
+	s[slug] = true
+
+	return slug
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2026 Matt Robinson brimstone@the.narro.ws
+
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/brimstone/plextraccli/utils"
+)
+
+func TestStripANSI(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{
+			name:     "plain",
+			text:     "SMB 10.0.0.5 445 DC01\n",
+			expected: "SMB 10.0.0.5 445 DC01\n",
+		},
+		{
+			name:     "colors",
+			text:     "\x1b[1m\x1b[34mSMB\x1b[0m 10.0.0.5 \x1b[1;32m[+]\x1b[0m corp\\admin\n",
+			expected: "SMB 10.0.0.5 [+] corp\\admin\n",
+		},
+		{
+			name:     "cursor movement and title",
+			text:     "\x1b]0;nxc\x07\x1b[2K\x1b[?25lscanning\x1b[?25h",
+			expected: "scanning",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := utils.StripANSI(tt.text)
+			if got != tt.expected {
+				t.Errorf("StripANSI() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		text     string
+		maxLines int
+		expected string
+	}{
+		{
+			name:     "short",
+			text:     "a\nb\n",
+			maxLines: 3,
+			expected: "a\nb\n",
+		},
+		{
+			name:     "no limit",
+			text:     "a\nb\nc\n",
+			maxLines: 0,
+			expected: "a\nb\nc\n",
+		},
+		{
+			name:     "keeps start and end",
+			text:     "a\nb\nc\nd\ne\nf\n",
+			maxLines: 3,
+			expected: "a\nb\n[… 3 lines truncated …]\nf\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := utils.Truncate(tt.text, tt.maxLines)
+			if got != tt.expected {
+				t.Errorf("Truncate() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSlug(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{name: "SMB Signing Not Required", expected: "smb-signing-not-required"},
+		{name: "  Executive Summary / Scope ", expected: "executive-summary-scope"},
+		{name: "MS17-010 (EternalBlue)", expected: "ms17-010-eternalblue"},
+		{name: "???", expected: "untitled"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := utils.Slug(tt.name)
+			if got != tt.expected {
+				t.Errorf("Slug(%q) = %q, want %q", tt.name, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSlugs(t *testing.T) {
+	t.Parallel()
+
+	slugs := utils.Slugs{}
+
+	names := []string{"SMB Signing", "SMB signing!", "Cleartext", "smb signing 2", "SMB Signing"}
+	expected := []string{"smb-signing", "smb-signing-2", "cleartext", "smb-signing-2-2", "smb-signing-3"}
+
+	for i, name := range names {
+		got := slugs.Slug(name)
+		if got != expected[i] {
+			t.Errorf("Slug(%q) = %q, want %q", name, got, expected[i])
+		}
+	}
+}
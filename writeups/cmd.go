@@ -8,13 +8,13 @@ import (
 	"io"
 	"log/slog"
 	"os"
+	"slices"
 	"sort"
 	"strings"
 
-	htmltomarkdown "github.com/JohannesKaufmann/html-to-markdown/v2"
 	"github.com/brimstone/plextraccli/plextrac"
+	"github.com/brimstone/plextraccli/richtext"
 	"github.com/brimstone/plextraccli/utils"
-	blackfriday "github.com/russross/blackfriday/v2"
 	"github.com/spf13/cobra"
 )
 
@@ -165,21 +165,21 @@ func cmdWriteupsGet(cmd *cobra.Command, args []string) error {
 	fmt.Printf("# %s\n", writeup.Title)
 	fmt.Printf("ID: %s\n\n", writeup.Cuid)
 
-	md, err := htmltomarkdown.ConvertString(writeup.Description)
+	md, err := richtext.ToMarkdown(writeup.Description)
 	if err != nil {
 		return err
 	}
 
 	fmt.Printf("## Description\n%s\n\n", md)
 
-	md, err = htmltomarkdown.ConvertString(writeup.Recommendations)
+	md, err = richtext.ToMarkdown(writeup.Recommendations)
 	if err != nil {
 		return err
 	}
 
 	fmt.Printf("## Recommendations\n%s\n\n", md)
 
-	md, err = htmltomarkdown.ConvertString(writeup.References)
+	md, err = richtext.ToMarkdown(writeup.References)
 	if err != nil {
 		return err
 	}
@@ -194,7 +194,7 @@ func cmdWriteupsGetDescription(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	md, err := htmltomarkdown.ConvertString(writeup.Description)
+	md, err := richtext.ToMarkdown(writeup.Description)
 	if err != nil {
 		return err
 	}
@@ -209,7 +209,7 @@ func cmdWriteupsGetRecommendations(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	md, err := htmltomarkdown.ConvertString(writeup.Recommendations)
+	md, err := richtext.ToMarkdown(writeup.Recommendations)
 	if err != nil {
 		return err
 	}
@@ -224,7 +224,7 @@ func cmdWriteupsGetReferences(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	md, err := htmltomarkdown.ConvertString(writeup.References)
+	md, err := richtext.ToMarkdown(writeup.References)
 	if err != nil {
 		return err
 	}
@@ -234,42 +234,63 @@ func cmdWriteupsGetReferences(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func parseMD(document *blackfriday.Node) (plextrac.Writeup, error) {
+// parseMD reads a writeup in the same layout `writeups get` prints. Each
+// section is converted back to HTML as a whole so nothing past the first
+// paragraph is lost.
+func parseMD(data []byte) (plextrac.Writeup, error) {
 	var w plextrac.Writeup
 
 	var location string
 
-	document.Walk(func(node *blackfriday.Node, entering bool) blackfriday.WalkStatus {
-		if !entering {
-			return blackfriday.GoToNext
+	var inFence bool
+
+	sections := make(map[string][]string)
+
+	for line := range strings.SplitSeq(string(data), "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
 		}
 
-		switch node.Type { //nolint:exhaustive
-		case blackfriday.Heading:
-			//fmt.Printf("Heading: %d\n", node.Level)
-			if node.Level == 1 {
-				w.Title = string(node.FirstChild.Literal)
-			}
+		switch {
+		case inFence:
+		case strings.HasPrefix(line, "# ") && location == "":
+			w.Title = strings.TrimSpace(strings.TrimPrefix(line, "# "))
 
-			if node.Level == 2 {
-				location = string(node.FirstChild.Literal)
-			}
-		case blackfriday.Paragraph:
-			//fmt.Printf("Text for %q: %s\n", location, node.FirstChild.Literal)
-			switch location {
-			case "":
-				// TODO parse out the ID
-			case "Description":
-				w.Description = string(node.FirstChild.Literal)
-			case "Recommendations":
-				w.Recommendations = string(node.FirstChild.Literal)
-			case "References":
-				w.References = string(node.FirstChild.Literal)
+			continue
+		case strings.HasPrefix(line, "ID: ") && location == "":
+			w.Cuid = strings.TrimSpace(strings.TrimPrefix(line, "ID: "))
+
+			continue
+		case strings.HasPrefix(line, "## "):
+			heading := strings.TrimSpace(strings.TrimPrefix(line, "## "))
+			if slices.Contains([]string{"Description", "Recommendations", "References"}, heading) {
+				location = heading
+
+				continue
 			}
 		}
 
-		return blackfriday.GoToNext
-	})
+		if location != "" {
+			sections[location] = append(sections[location], line)
+		}
+	}
+
+	for location, field := range map[string]*string{
+		"Description":     &w.Description,
+		"Recommendations": &w.Recommendations,
+		"References":      &w.References,
+	} {
+		content, err := richtext.ToHTML(strings.Join(sections[location], "\n"))
+		if err != nil {
+			return w, fmt.Errorf("while converting %s: %w", location, err)
+		}
+
+		*field = content
+	}
+
+	if w.Title == "" {
+		return w, errors.New("writeup is missing a title")
+	}
 
 	return w, nil
 }
@@ -287,10 +308,7 @@ func cmdWriteupsImport(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	fmt.Printf("%s\n\n\n", data)
-	output := blackfriday.New().Parse(data)
-
-	writeup, err := parseMD(output)
+	writeup, err := parseMD(data)
 	if err != nil {
 		return err
 	}